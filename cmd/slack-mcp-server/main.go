@@ -10,6 +10,7 @@ import (
 	"sync"
 
 	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/korotovsky/slack-mcp-server/pkg/realtime"
 	"github.com/korotovsky/slack-mcp-server/pkg/server"
 	"github.com/mattn/go-isatty"
 	"go.uber.org/zap"
@@ -39,15 +40,91 @@ func main() {
 		)
 	}
 
-	p := provider.New(transport, logger)
-	s := server.NewMCPServer(p, logger)
+	err = validateToolConfig(os.Getenv("SLACK_MCP_REACTION_TOOL"))
+	if err != nil {
+		logger.Fatal("error in SLACK_MCP_REACTION_TOOL",
+			zap.String("context", "console"),
+			zap.Error(err),
+		)
+	}
+
+	err = validateToolConfig(os.Getenv("SLACK_MCP_MARK_TOOL"))
+	if err != nil {
+		logger.Fatal("error in SLACK_MCP_MARK_TOOL",
+			zap.String("context", "console"),
+			zap.Error(err),
+		)
+	}
+
+	realtimeMode := os.Getenv("SLACK_MCP_REALTIME")
+	if realtimeMode != "" && realtimeMode != realtime.ModeRTM && realtimeMode != realtime.ModeSocketMode {
+		logger.Fatal("error in SLACK_MCP_REALTIME",
+			zap.String("context", "console"),
+			zap.String("mode", realtimeMode),
+			zap.String("allowed", realtime.ModeSocketMode+", "+realtime.ModeRTM),
+		)
+	}
+
+	if _, err := provider.CacheBackendFromEnv(); err != nil {
+		logger.Fatal("error in SLACK_MCP_CACHE_BACKEND",
+			zap.String("context", "console"),
+			zap.Error(err),
+		)
+	}
+
+	refreshInterval, err := provider.RefreshIntervalFromEnv()
+	if err != nil {
+		logger.Fatal("error in SLACK_MCP_CACHE_REFRESH_INTERVAL",
+			zap.String("context", "console"),
+			zap.Error(err),
+		)
+	}
+
+	workspaces := provider.NewWorkspaces(transport, logger)
+	p := workspaces.Default()
+
+	var ingestor *realtime.Ingestor
+	if realtimeMode != "" {
+		if workspaces.PerSession() {
+			logger.Fatal("SLACK_MCP_REALTIME cannot be combined with SLACK_MCP_SESSION_CREDENTIALS",
+				zap.String("context", "console"),
+			)
+		}
+		ingestor = realtime.New(p, logger)
+	}
+
+	s := server.NewMCPServer(workspaces, ingestor, logger)
 
-	go func() {
-		var once sync.Once
+	for i, w := range workspaces.All() {
+		wsLogger := logger
+		if w.Name != "" {
+			wsLogger = logger.With(zap.String("workspace", w.Name))
+		}
+
+		go func() {
+			var once sync.Once
+
+			newUsersWatcher(w.Provider, &once, wsLogger)()
+			newChannelsWatcher(w.Provider, &once, wsLogger)()
 
-		newUsersWatcher(p, &once, logger)()
-		newChannelsWatcher(p, &once, logger)()
-	}()
+			go w.Provider.RunRefresh(context.Background(), refreshInterval)
+
+			// Start listening once the caches are warm, so that the events
+			// update them instead of being overwritten by the initial load.
+			if i == 0 && ingestor != nil {
+				logger.Info("Starting real-time event ingestion...",
+					zap.String("context", "console"),
+					zap.String("mode", realtimeMode),
+				)
+				if err := ingestor.Run(context.Background(), realtimeMode); err != nil {
+					logger.Error("Real-time event ingestion stopped",
+						zap.String("context", "console"),
+						zap.Error(err),
+					)
+				}
+			}
+		}()
+	}
 
 	switch transport {
 	case "stdio":
@@ -75,7 +152,11 @@ func main() {
 			zap.String("port", port),
 		)
 
-		if ready, _ := p.IsReady(); !ready {
+		if p == nil {
+			logger.Info("Slack credentials are supplied by each session",
+				zap.String("context", "console"),
+			)
+		} else if ready, _ := p.IsReady(); !ready {
 			logger.Info("Slack MCP Server is still loading data",
 				zap.String("context", "console"),
 			)
@@ -105,7 +186,11 @@ func main() {
 			zap.String("port", port),
 		)
 
-		if ready, _ := p.IsReady(); !ready {
+		if p == nil {
+			logger.Info("Slack credentials are supplied by each session",
+				zap.String("context", "console"),
+			)
+		} else if ready, _ := p.IsReady(); !ready {
 			logger.Info("Slack MCP Server is still warming up caches",
 				zap.String("context", "console"),
 			)
@@ -132,7 +217,7 @@ func newUsersWatcher(p *provider.ApiProvider, once *sync.Once, logger *zap.Logge
 			zap.String("context", "console"),
 		)
 
-		if os.Getenv("SLACK_MCP_XOXP_TOKEN") == "demo" || (os.Getenv("SLACK_MCP_XOXC_TOKEN") == "demo" && os.Getenv("SLACK_MCP_XOXD_TOKEN") == "demo") {
+		if p.IsDemo() {
 			logger.Info("Demo credentials are set, skip",
 				zap.String("context", "console"),
 			)
@@ -164,7 +249,7 @@ func newChannelsWatcher(p *provider.ApiProvider, once *sync.Once, logger *zap.Lo
 			zap.String("context", "console"),
 		)
 
-		if os.Getenv("SLACK_MCP_XOXP_TOKEN") == "demo" || (os.Getenv("SLACK_MCP_XOXC_TOKEN") == "demo" && os.Getenv("SLACK_MCP_XOXD_TOKEN") == "demo") {
+		if p.IsDemo() {
 			logger.Info("Demo credentials are set, skip.",
 				zap.String("context", "console"),
 			)
@@ -0,0 +1,147 @@
+package handler
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/slack-go/slack"
+	"go.uber.org/zap"
+)
+
+type ChannelDetails struct {
+	ID                  string `json:"id"`
+	Name                string `json:"name"`
+	Type                string `json:"type"`
+	Topic               string `json:"topic"`
+	Purpose             string `json:"purpose"`
+	MemberCount         int    `json:"memberCount"`
+	CreatorID           string `json:"creatorID"`
+	CreatorName         string `json:"creatorName"`
+	Created             string `json:"created"`
+	IsArchived          bool   `json:"isArchived"`
+	IsGeneral           bool   `json:"isGeneral"`
+	IsMember            bool   `json:"isMember"`
+	IsReadOnly          bool   `json:"isReadOnly"`
+	IsShared            bool   `json:"isShared"`
+	IsExtShared         bool   `json:"isExtShared"`
+	IsOrgShared         bool   `json:"isOrgShared"`
+	IsPendingExtShared  bool   `json:"isPendingExtShared"`
+	PreviousNames       string `json:"previousNames"`
+	PostingRestrictedTo string `json:"postingRestrictedTo"`
+	ThreadsRestrictedTo string `json:"threadsRestrictedTo"`
+	CanvasFileID        string `json:"canvasFileID"`
+	Tabs                string `json:"tabs"`
+	Locale              string `json:"locale"`
+}
+
+// ChannelInfoHandler returns detailed information about a single channel
+func (ch *ChannelsHandler) ChannelInfoHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ch.logger.Debug("ChannelInfoHandler called", zap.Any("params", request.Params))
+
+	channelID, err := resolveChannelID(ctx, ch.apiProvider, ch.logger, request.GetString("channel_id", ""))
+	if err != nil {
+		return nil, err
+	}
+
+	channel, err := ch.apiProvider.Slack().GetConversationInfoContext(ctx, &slack.GetConversationInfoInput{
+		ChannelID:         channelID,
+		IncludeLocale:     true,
+		IncludeNumMembers: true,
+	})
+	if err != nil {
+		ch.logger.Error("Slack GetConversationInfoContext failed", zap.String("channel", channelID), zap.Error(err))
+		return nil, err
+	}
+
+	details := []ChannelDetails{ch.convertChannelDetails(ctx, channel)}
+	result, err := pageResult(request, details, "")
+	if err != nil {
+		ch.logger.Error("Failed to marshal channel info", zap.Error(err))
+		return nil, err
+	}
+	return result, nil
+}
+
+func (ch *ChannelsHandler) convertChannelDetails(ctx context.Context, c *slack.Channel) ChannelDetails {
+	usersMap := ch.apiProvider.ResolveUsers(ctx, []string{c.Creator})
+
+	// Prefer the cached name, it carries the #/@ prefix used by all tools
+	// and resolves DM partners to their handles.
+	name := c.Name
+	if cached, ok := ch.apiProvider.Channels().Get(c.ID); ok && cached.Name != "" {
+		name = cached.Name
+	} else if name != "" {
+		name = "#" + name
+	}
+
+	creatorName, _, _ := getUserInfo(c.Creator, usersMap)
+
+	details := ChannelDetails{
+		ID:                 c.ID,
+		Name:               name,
+		Type:               channelType(c),
+		Topic:              c.Topic.Value,
+		Purpose:            c.Purpose.Value,
+		MemberCount:        c.NumMembers,
+		CreatorID:          c.Creator,
+		CreatorName:        creatorName,
+		IsArchived:         c.IsArchived,
+		IsGeneral:          c.IsGeneral,
+		IsMember:           c.IsMember,
+		IsReadOnly:         c.IsReadOnly,
+		IsShared:           c.IsShared,
+		IsExtShared:        c.IsExtShared,
+		IsOrgShared:        c.IsOrgShared,
+		IsPendingExtShared: c.IsPendingExtShared,
+		PreviousNames:      strings.Join(c.PreviousNames, "|"),
+		Locale:             c.Locale,
+	}
+	if c.Created != 0 {
+		details.Created = c.Created.Time().UTC().Format(time.RFC3339)
+	}
+
+	if p := c.Properties; p != nil {
+		details.PostingRestrictedTo = formatRestrictedTo(p.PostingRestrictedTo)
+		details.ThreadsRestrictedTo = formatRestrictedTo(p.ThreadsRestrictedTo)
+		details.CanvasFileID = p.Canvas.FileId
+
+		var tabs []string
+		for _, tab := range p.Tabs {
+			label := tab.Label
+			if label == "" {
+				label = tab.Type
+			}
+			tabs = append(tabs, label)
+		}
+		details.Tabs = strings.Join(tabs, "|")
+	}
+
+	return details
+}
+
+func channelType(c *slack.Channel) string {
+	switch {
+	case c.IsIM:
+		return "im"
+	case c.IsMpIM:
+		return "mpim"
+	case c.IsPrivate:
+		return "private_channel"
+	default:
+		return "public_channel"
+	}
+}
+
+// formatRestrictedTo renders posting/thread restrictions as "type:admin|user:U123".
+func formatRestrictedTo(r slack.RestrictedTo) string {
+	var parts []string
+	for _, t := range r.Type {
+		parts = append(parts, "type:"+t)
+	}
+	for _, u := range r.User {
+		parts = append(parts, "user:"+u)
+	}
+	return strings.Join(parts, "|")
+}
@@ -0,0 +1,29 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnitChannelType(t *testing.T) {
+	channel := func(im, mpim, private bool) *slack.Channel {
+		c := &slack.Channel{}
+		c.IsIM, c.IsMpIM, c.IsPrivate = im, mpim, private
+		return c
+	}
+
+	assert.Equal(t, "im", channelType(channel(true, false, true)))
+	assert.Equal(t, "mpim", channelType(channel(false, true, true)))
+	assert.Equal(t, "private_channel", channelType(channel(false, false, true)))
+	assert.Equal(t, "public_channel", channelType(channel(false, false, false)))
+}
+
+func TestUnitFormatRestrictedTo(t *testing.T) {
+	assert.Equal(t, "", formatRestrictedTo(slack.RestrictedTo{}))
+	assert.Equal(t, "type:admin|user:U1|user:U2", formatRestrictedTo(slack.RestrictedTo{
+		Type: []string{"admin"},
+		User: []string{"U1", "U2"},
+	}))
+}
@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/slack-go/slack"
+	"go.uber.org/zap"
+)
+
+type ChannelMember struct {
+	UserID   string `json:"userID"`
+	UserName string `json:"userName"`
+	RealName string `json:"realName"`
+	Title    string `json:"title"`
+	IsBot    bool   `json:"isBot"`
+	IsGuest  bool   `json:"isGuest"`
+	IsAdmin  bool   `json:"isAdmin"`
+	IsMember bool   `json:"isMember"`
+	Cursor   string `json:"-"`
+}
+
+// ChannelMembersHandler lists members of a channel, or checks whether the
+// given users are members when the users parameter is set
+func (ch *ChannelsHandler) ChannelMembersHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ch.logger.Debug("ChannelMembersHandler called", zap.Any("params", request.Params))
+
+	channelID, err := resolveChannelID(ctx, ch.apiProvider, ch.logger, request.GetString("channel_id", ""))
+	if err != nil {
+		return nil, err
+	}
+
+	if raw := request.GetString("users", ""); raw != "" {
+		return ch.checkChannelMembership(ctx, request, channelID, raw)
+	}
+
+	limit := request.GetInt("limit", 100)
+	if limit <= 0 || limit > 1000 {
+		ch.logger.Warn("Limit out of range, using default", zap.Int("requested", limit))
+		limit = 100
+	}
+
+	ids, nextCursor, err := ch.apiProvider.Slack().GetUsersInConversationContext(ctx, &slack.GetUsersInConversationParameters{
+		ChannelID: channelID,
+		Cursor:    request.GetString("cursor", ""),
+		Limit:     limit,
+	})
+	if err != nil {
+		ch.logger.Error("Slack GetUsersInConversationContext failed", zap.String("channel", channelID), zap.Error(err))
+		return nil, err
+	}
+	ch.logger.Debug("Fetched channel members", zap.String("channel", channelID), zap.Int("count", len(ids)))
+
+	usersMaps := ch.apiProvider.ResolveUsers(ctx, ids)
+	includeBots := request.GetBool("include_bots", true)
+
+	var members []ChannelMember
+	for _, id := range ids {
+		member := newChannelMember(id, usersMaps, true)
+		if member.IsBot && !includeBots {
+			continue
+		}
+		members = append(members, member)
+	}
+	if len(members) > 0 && nextCursor != "" {
+		members[len(members)-1].Cursor = nextCursor
+	}
+
+	return pageResult(request, members, nextCursor)
+}
+
+func (ch *ChannelsHandler) checkChannelMembership(ctx context.Context, request mcp.CallToolRequest, channelID, raw string) (*mcp.CallToolResult, error) {
+	var ids []string
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		u, ok := lookupWithRefresh(ctx, ch.apiProvider, func() (slack.User, bool) {
+			return lookupUser(ch.apiProvider.Users(), part)
+		})
+		if !ok {
+			ch.logger.Error("User not found", zap.String("user", part))
+			return nil, fmt.Errorf("user %q not found", part)
+		}
+		ids = append(ids, u.ID)
+	}
+
+	nonMembers, err := ch.apiProvider.Slack().ChannelNonMembers(ctx, channelID, ids)
+	if err != nil {
+		ch.logger.Error("Slack ChannelNonMembers failed", zap.String("channel", channelID), zap.Error(err))
+		return nil, err
+	}
+
+	outside := make(map[string]struct{}, len(nonMembers))
+	for _, id := range nonMembers {
+		outside[id] = struct{}{}
+	}
+
+	users := ch.apiProvider.Users()
+	members := make([]ChannelMember, 0, len(ids))
+	for _, id := range ids {
+		_, notMember := outside[id]
+		members = append(members, newChannelMember(id, users, !notMember))
+	}
+	return pageResult(request, members, "")
+}
+
+func newChannelMember(id string, users *provider.UsersSnapshot, isMember bool) ChannelMember {
+	member := ChannelMember{UserID: id, IsMember: isMember}
+	if u, ok := users.Get(id); ok {
+		member.UserName = u.Name
+		member.RealName = u.RealName
+		member.Title = u.Profile.Title
+		member.IsBot = u.IsBot || u.IsAppUser
+		member.IsGuest = u.IsRestricted || u.IsUltraRestricted
+		member.IsAdmin = u.IsAdmin || u.IsOwner
+	}
+	return member
+}
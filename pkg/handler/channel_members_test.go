@@ -0,0 +1,20 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnitNewChannelMember(t *testing.T) {
+	users := provider.NewUsersSnapshot(testUsers())
+
+	m := newChannelMember("U1", users, true)
+	assert.Equal(t, ChannelMember{UserID: "U1", UserName: "jane", RealName: "Jane Doe", Title: "Payments Engineer", IsMember: true}, m)
+
+	assert.True(t, newChannelMember("B1", users, true).IsBot)
+
+	unknown := newChannelMember("U999", users, false)
+	assert.Equal(t, ChannelMember{UserID: "U999"}, unknown)
+}
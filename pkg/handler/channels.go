@@ -3,6 +3,7 @@ package handler
 import (
 	"context"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"sort"
 	"strings"
@@ -21,7 +22,7 @@ type Channel struct {
 	Topic       string `json:"topic"`
 	Purpose     string `json:"purpose"`
 	MemberCount int    `json:"memberCount"`
-	Cursor      string `json:"cursor"`
+	Cursor      string `json:"-"`
 }
 
 type ChannelsHandler struct {
@@ -74,7 +75,7 @@ func (ch *ChannelsHandler) ChannelsResource(ctx context.Context, request mcp.Rea
 		return nil, fmt.Errorf("failed to parse workspace from URL: %v", err)
 	}
 
-	channels := ch.apiProvider.ProvideChannelsMaps().Channels
+	channels := ch.apiProvider.Channels().All()
 	ch.logger.Debug("Retrieved channels from provider", zap.Int("count", len(channels)))
 
 	for _, channel := range channels {
@@ -156,7 +157,7 @@ func (ch *ChannelsHandler) ChannelsHandler(ctx context.Context, request mcp.Call
 		channelList []Channel
 	)
 
-	allChannels := ch.apiProvider.ProvideChannelsMaps().Channels
+	allChannels := ch.apiProvider.Channels().All()
 	ch.logger.Debug("Total channels available", zap.Int("count", len(allChannels)))
 
 	channels := filterChannelsByTypes(allChannels, channelTypes)
@@ -200,16 +201,54 @@ func (ch *ChannelsHandler) ChannelsHandler(ctx context.Context, request mcp.Call
 		ch.logger.Debug("Added cursor to last channel", zap.String("cursor", nextcur))
 	}
 
-	csvBytes, err := gocsv.MarshalBytes(&channelList)
+	result, err := pageResult(request, channelList, nextcur)
 	if err != nil {
-		ch.logger.Error("Failed to marshal channels to CSV", zap.Error(err))
+		ch.logger.Error("Failed to marshal channels", zap.Error(err))
 		return nil, err
 	}
 
-	return mcp.NewToolResultText(string(csvBytes)), nil
+	return result, nil
+}
+
+// resolveChannelID turns a channel ID or #channel / @user_dm name into an ID
+// using the channels cache.
+func resolveChannelID(ctx context.Context, apiProvider *provider.ApiProvider, logger *zap.Logger, channel string) (string, error) {
+	channel = strings.TrimSpace(channel)
+	if channel == "" {
+		logger.Error("channel_id missing in params")
+		return "", errors.New("channel_id must be a string")
+	}
+	if !strings.HasPrefix(channel, "#") && !strings.HasPrefix(channel, "@") {
+		return channel, nil
+	}
+
+	id, ok := lookupWithRefresh(ctx, apiProvider, func() (string, bool) {
+		return lookupChannelID(apiProvider, channel)
+	})
+	if !ok {
+		logger.Error("Channel not found", zap.String("channel", channel))
+		return "", fmt.Errorf("channel %q not found", channel)
+	}
+	return id, nil
+}
+
+// lookupChannelID finds a #channel or @user_dm name in the channels cache.
+func lookupChannelID(apiProvider *provider.ApiProvider, name string) (string, bool) {
+	c, ok := apiProvider.Channels().ByName(name)
+	return c.ID, ok
+}
+
+// lookupWithRefresh runs lookup and, when it misses, runs it again after
+// refreshing the caches: the name may be newer than the last refresh.
+func lookupWithRefresh[T any](ctx context.Context, apiProvider *provider.ApiProvider, lookup func() (T, bool)) (T, bool) {
+	v, ok := lookup()
+	if ok || !apiProvider.RefreshOnMiss(ctx) {
+		return v, ok
+	}
+	return lookup()
 }
 
-func filterChannelsByTypes(channels map[string]provider.Channel, types []string) []provider.Channel {
+func filterChannelsByTypes(channels []provider.Channel, types []string) []provider.Channel {
 	logger := zap.L()
 
 	var result []provider.Channel
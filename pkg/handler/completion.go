@@ -0,0 +1,198 @@
+package handler
+
+import (
+	"context"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/mark3labs/mcp-go/mcp"
+	"go.uber.org/zap"
+)
+
+// maxCompletionValues is the limit MCP puts on a completion response.
+const maxCompletionValues = 100
+
+type completionKind int
+
+const (
+	completeChannels completionKind = iota + 1
+	completeConversations
+	completeDMs
+	completeUsers
+)
+
+// completionArguments maps argument names of tools, prompts and resource
+// templates to the directory their values come from.
+var completionArguments = map[string]completionKind{
+	"channel_id":           completeConversations,
+	"channel":              completeConversations,
+	"channels":             completeConversations,
+	"filter_in_channel":    completeChannels,
+	"filter_in_im_or_mpim": completeDMs,
+	"user_id":              completeUsers,
+	"users":                completeUsers,
+	"filter_users_from":    completeUsers,
+	"filter_users_with":    completeUsers,
+}
+
+type CompletionsHandler struct {
+	apiProvider *provider.ApiProvider
+	logger      *zap.Logger
+}
+
+func NewCompletionsHandler(apiProvider *provider.ApiProvider, logger *zap.Logger) *CompletionsHandler {
+	return &CompletionsHandler{
+		apiProvider: apiProvider,
+		logger:      logger,
+	}
+}
+
+// Complete suggests channel and user names for the argument being typed,
+// served from the caches. Prefix matches rank before substring matches,
+// channels with more members first.
+func (h *CompletionsHandler) Complete(ctx context.Context, request mcp.CompleteRequest) (*mcp.CompleteResult, error) {
+	h.logger.Debug("Complete called", zap.Any("params", request.Params))
+
+	name, value := request.Params.Argument.Name, request.Params.Argument.Value
+	result := &mcp.CompleteResult{}
+	result.Completion.Values = []string{}
+
+	kind, ok := completionArguments[name]
+	if !ok {
+		return result, nil
+	}
+
+	// Resource template variables end up in a URI, so names are escaped the
+	// way resourceArgument expects them.
+	escape := completionRefType(request.Params.Ref) == "ref/resource"
+
+	// Lists like users='@jane,@jo' complete their last element. Names never
+	// contain commas, so this is safe for single values too.
+	var prefix string
+	if idx := strings.LastIndex(value, ","); idx >= 0 {
+		prefix, value = value[:idx+1], value[idx+1:]
+	}
+
+	var values []string
+	if kind == completeUsers {
+		values = completeUserNames(h.apiProvider.Users(), strings.TrimSpace(value))
+	} else {
+		values = completeChannelNames(h.apiProvider.Channels(), kind, strings.TrimSpace(value))
+	}
+
+	result.Completion.Total = len(values)
+	if len(values) > maxCompletionValues {
+		values = values[:maxCompletionValues]
+		result.Completion.HasMore = true
+	}
+	for _, v := range values {
+		if escape {
+			v = url.PathEscape(v)
+		}
+		result.Completion.Values = append(result.Completion.Values, prefix+v)
+	}
+	return result, nil
+}
+
+func completeChannelNames(channels *provider.ChannelsSnapshot, kind completionKind, value string) []string {
+	query := strings.ToLower(strings.TrimLeft(value, "#@"))
+
+	type scored struct {
+		channel provider.Channel
+		prefix  bool
+	}
+	var matches []scored
+	for _, c := range channels.All() {
+		isDM := c.IsIM || c.IsMpIM
+		if (kind == completeChannels && isDM) || (kind == completeDMs && !isDM) {
+			continue
+		}
+		if c.ID == value {
+			matches = append(matches, scored{channel: c, prefix: true})
+			continue
+		}
+		name := strings.ToLower(strings.TrimLeft(c.Name, "#@"))
+		switch {
+		case strings.HasPrefix(name, query):
+			matches = append(matches, scored{channel: c, prefix: true})
+		case strings.Contains(name, query):
+			matches = append(matches, scored{channel: c})
+		}
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		a, b := matches[i], matches[j]
+		if a.prefix != b.prefix {
+			return a.prefix
+		}
+		if a.channel.MemberCount != b.channel.MemberCount {
+			return a.channel.MemberCount > b.channel.MemberCount
+		}
+		return a.channel.Name < b.channel.Name
+	})
+
+	values := make([]string, 0, len(matches))
+	for _, m := range matches {
+		values = append(values, m.channel.Name)
+	}
+	return values
+}
+
+func completeUserNames(users *provider.UsersSnapshot, value string) []string {
+	query := strings.ToLower(strings.TrimPrefix(value, "@"))
+
+	type scored struct {
+		name  string
+		score int
+	}
+	var matches []scored
+	for _, u := range users.All() {
+		if u.Deleted || u.Name == "" {
+			continue
+		}
+		handle := strings.ToLower(u.Name)
+		realName := strings.ToLower(u.RealName)
+		displayName := strings.ToLower(u.Profile.DisplayName)
+
+		score := 0
+		switch {
+		case u.ID == value || strings.HasPrefix(handle, query):
+			score = 3
+		case strings.HasPrefix(realName, query) || strings.HasPrefix(displayName, query):
+			score = 2
+		case strings.Contains(handle, query) || strings.Contains(realName, query) || strings.Contains(displayName, query):
+			score = 1
+		}
+		if score > 0 {
+			matches = append(matches, scored{name: "@" + u.Name, score: score})
+		}
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].score != matches[j].score {
+			return matches[i].score > matches[j].score
+		}
+		return matches[i].name < matches[j].name
+	})
+
+	values := make([]string, 0, len(matches))
+	for _, m := range matches {
+		values = append(values, m.name)
+	}
+	return values
+}
+
+func completionRefType(ref any) string {
+	switch r := ref.(type) {
+	case map[string]any:
+		t, _ := r["type"].(string)
+		return t
+	case mcp.ResourceReference:
+		return r.Type
+	case mcp.PromptReference:
+		return r.Type
+	}
+	return ""
+}
@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestUnitCompletionsHandler(t *testing.T) {
+	t.Setenv("SLACK_MCP_XOXP_TOKEN", "demo")
+	p := provider.New("stdio", zap.NewNop())
+
+	p.UpsertChannel(provider.Channel{ID: "C1", Name: "#general", MemberCount: 10})
+	p.UpsertChannel(provider.Channel{ID: "C2", Name: "#gen-ai", MemberCount: 50})
+	p.UpsertChannel(provider.Channel{ID: "C3", Name: "#team-general", MemberCount: 100})
+	p.UpsertChannel(provider.Channel{ID: "D1", Name: "@genevieve", IsIM: true})
+
+	p.UpsertUser(slack.User{ID: "U1", Name: "jane", RealName: "Jane Doe"})
+	p.UpsertUser(slack.User{ID: "U2", Name: "jdoe", RealName: "John Doe"})
+	p.UpsertUser(slack.User{ID: "U3", Name: "ajo", RealName: "Jo Anderson"})
+	p.UpsertUser(slack.User{ID: "U4", Name: "jack", Deleted: true})
+
+	ch := NewCompletionsHandler(p, zap.NewNop())
+
+	complete := func(refType, name, value string) *mcp.CompleteResult {
+		var req mcp.CompleteRequest
+		req.Params.Ref = map[string]any{"type": refType}
+		req.Params.Argument.Name = name
+		req.Params.Argument.Value = value
+		res, err := ch.Complete(context.Background(), req)
+		require.NoError(t, err)
+		return res
+	}
+
+	t.Run("prefix matches rank before substring, then by members", func(t *testing.T) {
+		res := complete("ref/prompt", "channel", "#gen")
+		assert.Equal(t, []string{"#gen-ai", "#general", "@genevieve", "#team-general"}, res.Completion.Values)
+		assert.Equal(t, 4, res.Completion.Total)
+		assert.False(t, res.Completion.HasMore)
+	})
+
+	t.Run("public channel filter excludes DMs", func(t *testing.T) {
+		res := complete("ref/prompt", "filter_in_channel", "gen")
+		assert.Equal(t, []string{"#gen-ai", "#general", "#team-general"}, res.Completion.Values)
+	})
+
+	t.Run("users complete the last list element", func(t *testing.T) {
+		res := complete("ref/prompt", "users", "@jane,jo")
+		assert.Equal(t, []string{"@jane,@ajo", "@jane,@jdoe"}, res.Completion.Values)
+	})
+
+	t.Run("channel lists complete the last element", func(t *testing.T) {
+		res := complete("ref/prompt", "channels", "#general, team")
+		assert.Equal(t, []string{"#general,#team-general"}, res.Completion.Values)
+	})
+
+	t.Run("deleted users are skipped", func(t *testing.T) {
+		res := complete("ref/prompt", "user_id", "jack")
+		assert.Empty(t, res.Completion.Values)
+	})
+
+	t.Run("resource template values are escaped", func(t *testing.T) {
+		res := complete("ref/resource", "channel", "#general")
+		assert.Equal(t, []string{"%23general", "%23team-general"}, res.Completion.Values)
+	})
+
+	t.Run("unknown argument", func(t *testing.T) {
+		res := complete("ref/prompt", "query", "gen")
+		assert.NotNil(t, res.Completion.Values)
+		assert.Empty(t, res.Completion.Values)
+	})
+}
@@ -41,14 +41,15 @@ var validFilterKeys = map[string]struct{}{
 type Message struct {
 	MsgID     string `json:"msgID"`
 	UserID    string `json:"userID"`
-	UserName  string `json:"userUser"`
+	UserName  string `json:"userName"`
 	RealName  string `json:"realName"`
 	Channel   string `json:"channelID"`
-	ThreadTs  string `json:"ThreadTs"`
+	ThreadTs  string `json:"threadTs"`
 	Text      string `json:"text"`
 	Time      string `json:"time"`
 	Reactions string `json:"reactions,omitempty"`
-	Cursor    string `json:"cursor"`
+	Files     string `json:"files,omitempty"`
+	Cursor    string `json:"-"`
 }
 
 type User struct {
@@ -124,8 +125,7 @@ func (ch *ConversationsHandler) UsersResource(ctx context.Context, request mcp.R
 	}
 
 	// collect users
-	usersMaps := ch.apiProvider.ProvideUsersMap()
-	users := usersMaps.Users
+	users := ch.apiProvider.Users().All()
 	usersList := make([]User, 0, len(users))
 	for _, user := range users {
 		usersList = append(usersList, User{
@@ -155,7 +155,7 @@ func (ch *ConversationsHandler) UsersResource(ctx context.Context, request mcp.R
 func (ch *ConversationsHandler) ConversationsAddMessageHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	ch.logger.Debug("ConversationsAddMessageHandler called", zap.Any("params", request.Params))
 
-	params, err := ch.parseParamsToolAddMessage(request)
+	params, err := ch.parseParamsToolAddMessage(ctx, request, "conversations_add_message")
 	if err != nil {
 		ch.logger.Error("Failed to parse add-message params", zap.Error(err))
 		return nil, err
@@ -166,30 +166,13 @@ func (ch *ConversationsHandler) ConversationsAddMessageHandler(ctx context.Conte
 		options = append(options, slack.MsgOptionTS(params.threadTs))
 	}
 
-	switch params.contentType {
-	case "text/plain":
-		options = append(options, slack.MsgOptionDisableMarkdown())
-		options = append(options, slack.MsgOptionText(params.text, false))
-	case "text/markdown":
-		blocks, err := slackGoUtil.ConvertMarkdownTextToBlocks(params.text)
-		if err != nil {
-			ch.logger.Warn("Markdown parsing error", zap.Error(err))
-			options = append(options, slack.MsgOptionDisableMarkdown())
-			options = append(options, slack.MsgOptionText(params.text, false))
-		} else {
-			options = append(options, slack.MsgOptionBlocks(blocks...))
-		}
-	default:
-		return nil, errors.New("content_type must be either 'text/plain' or 'text/markdown'")
+	contentOptions, err := ch.messageContentOptions(params.text, params.contentType)
+	if err != nil {
+		return nil, err
 	}
+	options = append(options, contentOptions...)
 
-	unfurlOpt := os.Getenv("SLACK_MCP_ADD_MESSAGE_UNFURLING")
-	if text.IsUnfurlingEnabled(params.text, unfurlOpt, ch.logger) {
-		options = append(options, slack.MsgOptionEnableLinkUnfurl())
-	} else {
-		options = append(options, slack.MsgOptionDisableLinkUnfurl())
-		options = append(options, slack.MsgOptionDisableMediaUnfurl())
-	}
+	options = append(options, ch.unfurlOptions(params.text)...)
 
 	ch.logger.Debug("Posting Slack message",
 		zap.String("channel", params.channel),
@@ -226,15 +209,15 @@ func (ch *ConversationsHandler) ConversationsAddMessageHandler(ctx context.Conte
 	}
 	ch.logger.Debug("Fetched conversation history", zap.Int("message_count", len(history.Messages)))
 
-	messages := ch.convertMessagesFromHistory(history.Messages, historyParams.ChannelID, false)
-	return marshalMessagesToCSV(messages)
+	messages := ch.convertMessagesFromHistory(ctx, history.Messages, historyParams.ChannelID, false)
+	return marshalMessages(request, messages)
 }
 
 // ConversationsHistoryHandler streams conversation history as CSV
 func (ch *ConversationsHandler) ConversationsHistoryHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	ch.logger.Debug("ConversationsHistoryHandler called", zap.Any("params", request.Params))
 
-	params, err := ch.parseParamsToolConversations(request)
+	params, err := ch.parseParamsToolConversations(ctx, request)
 	if err != nil {
 		ch.logger.Error("Failed to parse history params", zap.Error(err))
 		return nil, err
@@ -263,19 +246,19 @@ func (ch *ConversationsHandler) ConversationsHistoryHandler(ctx context.Context,
 
 	ch.logger.Debug("Fetched conversation history", zap.Int("message_count", len(history.Messages)))
 
-	messages := ch.convertMessagesFromHistory(history.Messages, params.channel, params.activity)
+	messages := ch.convertMessagesFromHistory(ctx, history.Messages, params.channel, params.activity)
 
 	if len(messages) > 0 && history.HasMore {
 		messages[len(messages)-1].Cursor = history.ResponseMetaData.NextCursor
 	}
-	return marshalMessagesToCSV(messages)
+	return marshalMessages(request, messages)
 }
 
 // ConversationsRepliesHandler streams thread replies as CSV
 func (ch *ConversationsHandler) ConversationsRepliesHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	ch.logger.Debug("ConversationsRepliesHandler called", zap.Any("params", request.Params))
 
-	params, err := ch.parseParamsToolConversations(request)
+	params, err := ch.parseParamsToolConversations(ctx, request)
 	if err != nil {
 		ch.logger.Error("Failed to parse replies params", zap.Error(err))
 		return nil, err
@@ -302,17 +285,17 @@ func (ch *ConversationsHandler) ConversationsRepliesHandler(ctx context.Context,
 	}
 	ch.logger.Debug("Fetched conversation replies", zap.Int("count", len(replies)))
 
-	messages := ch.convertMessagesFromHistory(replies, params.channel, params.activity)
+	messages := ch.convertMessagesFromHistory(ctx, replies, params.channel, params.activity)
 	if len(messages) > 0 && hasMore {
 		messages[len(messages)-1].Cursor = nextCursor
 	}
-	return marshalMessagesToCSV(messages)
+	return marshalMessages(request, messages)
 }
 
 func (ch *ConversationsHandler) ConversationsSearchHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	ch.logger.Debug("ConversationsSearchHandler called", zap.Any("params", request.Params))
 
-	params, err := ch.parseParamsToolSearch(request)
+	params, err := ch.parseParamsToolSearch(ctx, request)
 	if err != nil {
 		ch.logger.Error("Failed to parse search params", zap.Error(err))
 		return nil, err
@@ -326,23 +309,34 @@ func (ch *ConversationsHandler) ConversationsSearchHandler(ctx context.Context,
 		Count:         params.limit,
 		Page:          params.page,
 	}
-	messagesRes, _, err := ch.apiProvider.Slack().SearchContext(ctx, params.query, searchParams)
+	messagesRes, filesRes, err := ch.apiProvider.Slack().SearchContext(ctx, params.query, searchParams)
 	if err != nil {
 		ch.logger.Error("Slack SearchContext failed", zap.Error(err))
 		return nil, err
 	}
 	ch.logger.Debug("Search completed", zap.Int("matches", len(messagesRes.Matches)))
 
-	messages := ch.convertMessagesFromSearch(messagesRes.Matches)
+	var matchedFiles []slack.File
+	if filesRes != nil {
+		matchedFiles = filesRes.Matches
+	}
+
+	messages := ch.convertMessagesFromSearch(ctx, messagesRes.Matches, matchedFiles)
 	if len(messages) > 0 && ((messagesRes.Pagination.PerPage * messagesRes.Pagination.PageCount) < messagesRes.Pagination.TotalCount) {
 		nextCursor := fmt.Sprintf("page:%d", messagesRes.Pagination.PageCount+1)
 		messages[len(messages)-1].Cursor = base64.StdEncoding.EncodeToString([]byte(nextCursor))
 	}
-	return marshalMessagesToCSV(messages)
+	return marshalMessages(request, messages)
 }
 
 func isChannelAllowed(channel string) bool {
-	config := os.Getenv("SLACK_MCP_ADD_MESSAGE_TOOL")
+	return isChannelAllowedByConfig(channel, os.Getenv("SLACK_MCP_ADD_MESSAGE_TOOL"))
+}
+
+// isChannelAllowedByConfig applies an allow/deny channel policy in the format of
+// SLACK_MCP_ADD_MESSAGE_TOOL: empty, true or 1 allows all channels, otherwise a
+// comma-separated list of allowed IDs or !-prefixed denied IDs.
+func isChannelAllowedByConfig(channel, config string) bool {
 	if config == "" || config == "true" || config == "1" {
 		return true
 	}
@@ -360,11 +354,17 @@ func isChannelAllowed(channel string) bool {
 			}
 		}
 	}
-	return !isNegated
+	// Channels missing from an allow list are rejected, channels missing
+	// from a deny list are permitted.
+	return isNegated
 }
 
-func (ch *ConversationsHandler) convertMessagesFromHistory(slackMessages []slack.Message, channel string, includeActivity bool) []Message {
-	usersMap := ch.apiProvider.ProvideUsersMap()
+func (ch *ConversationsHandler) convertMessagesFromHistory(ctx context.Context, slackMessages []slack.Message, channel string, includeActivity bool) []Message {
+	ids := make([]string, 0, len(slackMessages))
+	for _, msg := range slackMessages {
+		ids = append(ids, msg.User)
+	}
+	usersMap := ch.apiProvider.ResolveUsers(ctx, ids)
 	var messages []Message
 	warn := false
 
@@ -373,7 +373,7 @@ func (ch *ConversationsHandler) convertMessagesFromHistory(slackMessages []slack
 			continue
 		}
 
-		userName, realName, ok := getUserInfo(msg.User, usersMap.Users)
+		userName, realName, ok := getUserInfo(msg.User, usersMap)
 
 		if !ok && msg.SubType == "bot_message" {
 			userName, realName, ok = getBotInfo(msg.Username)
@@ -407,6 +407,7 @@ func (ch *ConversationsHandler) convertMessagesFromHistory(slackMessages []slack
 			ThreadTs:  msg.ThreadTimestamp,
 			Time:      timestamp,
 			Reactions: reactionsString,
+			Files:     formatMessageFiles(msg.Files),
 		})
 	}
 
@@ -421,13 +422,18 @@ func (ch *ConversationsHandler) convertMessagesFromHistory(slackMessages []slack
 	return messages
 }
 
-func (ch *ConversationsHandler) convertMessagesFromSearch(slackMessages []slack.SearchMessage) []Message {
-	usersMap := ch.apiProvider.ProvideUsersMap()
+func (ch *ConversationsHandler) convertMessagesFromSearch(ctx context.Context, slackMessages []slack.SearchMessage, files []slack.File) []Message {
+	ids := make([]string, 0, len(slackMessages))
+	for _, msg := range slackMessages {
+		ids = append(ids, msg.User)
+	}
+	usersMap := ch.apiProvider.ResolveUsers(ctx, ids)
+	filesByMessage := indexFilesByMessage(files)
 	var messages []Message
 	warn := false
 
 	for _, msg := range slackMessages {
-		userName, realName, ok := getUserInfo(msg.User, usersMap.Users)
+		userName, realName, ok := getUserInfo(msg.User, usersMap)
 
 		if !ok && msg.User == "" && msg.Username != "" {
 			userName, realName, ok = getBotInfo(msg.Username)
@@ -455,6 +461,7 @@ func (ch *ConversationsHandler) convertMessagesFromSearch(slackMessages []slack.
 			ThreadTs:  threadTs,
 			Time:      timestamp,
 			Reactions: "",
+			Files:     formatMessageFiles(filesByMessage[msg.Channel.ID+"/"+msg.Timestamp]),
 		})
 	}
 
@@ -469,7 +476,7 @@ func (ch *ConversationsHandler) convertMessagesFromSearch(slackMessages []slack.
 	return messages
 }
 
-func (ch *ConversationsHandler) parseParamsToolConversations(request mcp.CallToolRequest) (*conversationParams, error) {
+func (ch *ConversationsHandler) parseParamsToolConversations(ctx context.Context, request mcp.CallToolRequest) (*conversationParams, error) {
 	channel := request.GetString("channel_id", "")
 	if channel == "" {
 		ch.logger.Error("channel_id missing in conversations params")
@@ -516,13 +523,14 @@ func (ch *ConversationsHandler) parseParamsToolConversations(request mcp.CallToo
 			}
 			return nil, fmt.Errorf("channel %q not found, data not yet loaded", channel)
 		}
-		channelsMaps := ch.apiProvider.ProvideChannelsMaps()
-		chn, ok := channelsMaps.ChannelsInv[channel]
+		id, ok := lookupWithRefresh(ctx, ch.apiProvider, func() (string, bool) {
+			return lookupChannelID(ch.apiProvider, channel)
+		})
 		if !ok {
 			ch.logger.Error("Channel not found in loaded data", zap.String("channel", channel))
-			return nil, fmt.Errorf("channel %q not found in loaded data. Try restarting MCP Server", channel)
+			return nil, fmt.Errorf("channel %q not found in loaded data", channel)
 		}
-		channel = channelsMaps.Channels[chn].ID
+		channel = id
 	}
 
 	return &conversationParams{
@@ -535,15 +543,52 @@ func (ch *ConversationsHandler) parseParamsToolConversations(request mcp.CallToo
 	}, nil
 }
 
-func (ch *ConversationsHandler) parseParamsToolAddMessage(request mcp.CallToolRequest) (*addMessageParams, error) {
+// messageContentOptions converts a payload of the given content type into
+// message options, falling back to plain text when markdown cannot be parsed.
+func (ch *ConversationsHandler) messageContentOptions(msgText, contentType string) ([]slack.MsgOption, error) {
+	switch contentType {
+	case "text/plain":
+		return []slack.MsgOption{
+			slack.MsgOptionDisableMarkdown(),
+			slack.MsgOptionText(msgText, false),
+		}, nil
+	case "text/markdown":
+		blocks, err := slackGoUtil.ConvertMarkdownTextToBlocks(msgText)
+		if err != nil {
+			ch.logger.Warn("Markdown parsing error", zap.Error(err))
+			return []slack.MsgOption{
+				slack.MsgOptionDisableMarkdown(),
+				slack.MsgOptionText(msgText, false),
+			}, nil
+		}
+		return []slack.MsgOption{slack.MsgOptionBlocks(blocks...)}, nil
+	default:
+		return nil, errors.New("content_type must be either 'text/plain' or 'text/markdown'")
+	}
+}
+
+// unfurlOptions enables link unfurling only when SLACK_MCP_ADD_MESSAGE_UNFURLING
+// allows it for every link in the text.
+func (ch *ConversationsHandler) unfurlOptions(msgText string) []slack.MsgOption {
+	unfurlOpt := os.Getenv("SLACK_MCP_ADD_MESSAGE_UNFURLING")
+	if text.IsUnfurlingEnabled(msgText, unfurlOpt, ch.logger) {
+		return []slack.MsgOption{slack.MsgOptionEnableLinkUnfurl()}
+	}
+	return []slack.MsgOption{
+		slack.MsgOptionDisableLinkUnfurl(),
+		slack.MsgOptionDisableMediaUnfurl(),
+	}
+}
+
+func (ch *ConversationsHandler) parseParamsToolAddMessage(ctx context.Context, request mcp.CallToolRequest, tool string) (*addMessageParams, error) {
 	toolConfig := os.Getenv("SLACK_MCP_ADD_MESSAGE_TOOL")
 	if toolConfig == "" {
-		ch.logger.Error("Add-message tool disabled by default")
-		return nil, errors.New(
-			"by default, the conversations_add_message tool is disabled to guard Slack workspaces against accidental spamming." +
-				"To enable it, set the SLACK_MCP_ADD_MESSAGE_TOOL environment variable to true, 1, or comma separated list of channels" +
-				"to limit where the MCP can post messages, e.g. 'SLACK_MCP_ADD_MESSAGE_TOOL=C1234567890,D0987654321', 'SLACK_MCP_ADD_MESSAGE_TOOL=!C1234567890'" +
-				"to enable all except one or 'SLACK_MCP_ADD_MESSAGE_TOOL=true' for all channels and DMs",
+		ch.logger.Error("Add-message tool disabled by default", zap.String("tool", tool))
+		return nil, fmt.Errorf(
+			"by default, the %s tool is disabled to guard Slack workspaces against accidental spamming."+
+				"To enable it, set the SLACK_MCP_ADD_MESSAGE_TOOL environment variable to true, 1, or comma separated list of channels"+
+				"to limit where the MCP can post messages, e.g. 'SLACK_MCP_ADD_MESSAGE_TOOL=C1234567890,D0987654321', 'SLACK_MCP_ADD_MESSAGE_TOOL=!C1234567890'"+
+				"to enable all except one or 'SLACK_MCP_ADD_MESSAGE_TOOL=true' for all channels and DMs", tool,
 		)
 	}
 
@@ -553,17 +598,18 @@ func (ch *ConversationsHandler) parseParamsToolAddMessage(request mcp.CallToolRe
 		return nil, errors.New("channel_id must be a string")
 	}
 	if strings.HasPrefix(channel, "#") || strings.HasPrefix(channel, "@") {
-		channelsMaps := ch.apiProvider.ProvideChannelsMaps()
-		chn, ok := channelsMaps.ChannelsInv[channel]
+		id, ok := lookupWithRefresh(ctx, ch.apiProvider, func() (string, bool) {
+			return lookupChannelID(ch.apiProvider, channel)
+		})
 		if !ok {
 			ch.logger.Error("Channel not found", zap.String("channel", channel))
 			return nil, fmt.Errorf("channel %q not found", channel)
 		}
-		channel = channelsMaps.Channels[chn].ID
+		channel = id
 	}
 	if !isChannelAllowed(channel) {
 		ch.logger.Warn("Add-message tool not allowed for channel", zap.String("channel", channel), zap.String("policy", toolConfig))
-		return nil, fmt.Errorf("conversations_add_message tool is not allowed for channel %q, applied policy: %s", channel, toolConfig)
+		return nil, fmt.Errorf("%s tool is not allowed for channel %q, applied policy: %s", tool, channel, toolConfig)
 	}
 
 	threadTs := request.GetString("thread_ts", "")
@@ -592,7 +638,7 @@ func (ch *ConversationsHandler) parseParamsToolAddMessage(request mcp.CallToolRe
 	}, nil
 }
 
-func (ch *ConversationsHandler) parseParamsToolSearch(req mcp.CallToolRequest) (*searchParams, error) {
+func (ch *ConversationsHandler) parseParamsToolSearch(ctx context.Context, req mcp.CallToolRequest) (*searchParams, error) {
 	rawQuery := strings.TrimSpace(req.GetString("search_query", ""))
 	freeText, filters := splitQuery(rawQuery)
 
@@ -600,14 +646,14 @@ func (ch *ConversationsHandler) parseParamsToolSearch(req mcp.CallToolRequest) (
 		addFilter(filters, "is", "thread")
 	}
 	if chName := req.GetString("filter_in_channel", ""); chName != "" {
-		f, err := ch.paramFormatChannel(chName)
+		f, err := ch.paramFormatChannel(ctx, chName)
 		if err != nil {
 			ch.logger.Error("Invalid channel filter", zap.String("filter", chName), zap.Error(err))
 			return nil, err
 		}
 		addFilter(filters, "in", f)
 	} else if im := req.GetString("filter_in_im_or_mpim", ""); im != "" {
-		f, err := ch.paramFormatUser(im)
+		f, err := ch.paramFormatUser(ctx, im)
 		if err != nil {
 			ch.logger.Error("Invalid IM/MPIM filter", zap.String("filter", im), zap.Error(err))
 			return nil, err
@@ -615,7 +661,7 @@ func (ch *ConversationsHandler) parseParamsToolSearch(req mcp.CallToolRequest) (
 		addFilter(filters, "in", f)
 	}
 	if with := req.GetString("filter_users_with", ""); with != "" {
-		f, err := ch.paramFormatUser(with)
+		f, err := ch.paramFormatUser(ctx, with)
 		if err != nil {
 			ch.logger.Error("Invalid with-user filter", zap.String("filter", with), zap.Error(err))
 			return nil, err
@@ -623,7 +669,7 @@ func (ch *ConversationsHandler) parseParamsToolSearch(req mcp.CallToolRequest) (
 		addFilter(filters, "with", f)
 	}
 	if from := req.GetString("filter_users_from", ""); from != "" {
-		f, err := ch.paramFormatUser(from)
+		f, err := ch.paramFormatUser(ctx, from)
 		if err != nil {
 			ch.logger.Error("Invalid from-user filter", zap.String("filter", from), zap.Error(err))
 			return nil, err
@@ -685,11 +731,12 @@ func (ch *ConversationsHandler) parseParamsToolSearch(req mcp.CallToolRequest) (
 	}, nil
 }
 
-func (ch *ConversationsHandler) paramFormatUser(raw string) (string, error) {
-	users := ch.apiProvider.ProvideUsersMap()
+func (ch *ConversationsHandler) paramFormatUser(ctx context.Context, raw string) (string, error) {
 	raw = strings.TrimSpace(raw)
 	if strings.HasPrefix(raw, "U") {
-		u, ok := users.Users[raw]
+		u, ok := lookupWithRefresh(ctx, ch.apiProvider, func() (slack.User, bool) {
+			return ch.apiProvider.Users().Get(raw)
+		})
 		if !ok {
 			return "", fmt.Errorf("user %q not found", raw)
 		}
@@ -701,41 +748,46 @@ func (ch *ConversationsHandler) paramFormatUser(raw string) (string, error) {
 	if strings.HasPrefix(raw, "@") {
 		raw = raw[1:]
 	}
-	uid, ok := users.UsersInv[raw]
+	u, ok := lookupWithRefresh(ctx, ch.apiProvider, func() (slack.User, bool) {
+		return ch.apiProvider.Users().ByName(raw)
+	})
 	if !ok {
 		return "", fmt.Errorf("user %q not found", raw)
 	}
-	return fmt.Sprintf("<@%s>", uid), nil
+	return fmt.Sprintf("<@%s>", u.ID), nil
 }
 
-func (ch *ConversationsHandler) paramFormatChannel(raw string) (string, error) {
+func (ch *ConversationsHandler) paramFormatChannel(ctx context.Context, raw string) (string, error) {
 	raw = strings.TrimSpace(raw)
-	cms := ch.apiProvider.ProvideChannelsMaps()
-	if strings.HasPrefix(raw, "#") {
-		if id, ok := cms.ChannelsInv[raw]; ok {
-			return "#" + cms.Channels[id].Name, nil
-		}
-		return "", fmt.Errorf("channel %q not found", raw)
-	}
-	if strings.HasPrefix(raw, "C") {
-		if chn, ok := cms.Channels[raw]; ok {
-			return "#" + chn.Name, nil
+	if !strings.HasPrefix(raw, "#") && !strings.HasPrefix(raw, "C") {
+		return "", fmt.Errorf("invalid channel format: %q", raw)
+	}
+	name, ok := lookupWithRefresh(ctx, ch.apiProvider, func() (string, bool) {
+		channels := ch.apiProvider.Channels()
+		chn, ok := channels.Get(raw)
+		if strings.HasPrefix(raw, "#") {
+			chn, ok = channels.ByName(raw)
 		}
+		return chn.Name, ok
+	})
+	if !ok {
 		return "", fmt.Errorf("channel %q not found", raw)
 	}
-	return "", fmt.Errorf("invalid channel format: %q", raw)
+	return "#" + name, nil
 }
 
-func marshalMessagesToCSV(messages []Message) (*mcp.CallToolResult, error) {
-	csvBytes, err := gocsv.MarshalBytes(&messages)
-	if err != nil {
-		return nil, err
+// marshalMessages renders messages as a tool result, taking the cursor from
+// the last message.
+func marshalMessages(request mcp.CallToolRequest, messages []Message) (*mcp.CallToolResult, error) {
+	var cursor string
+	if len(messages) > 0 {
+		cursor = messages[len(messages)-1].Cursor
 	}
-	return mcp.NewToolResultText(string(csvBytes)), nil
+	return pageResult(request, messages, cursor)
 }
 
-func getUserInfo(userID string, usersMap map[string]slack.User) (userName, realName string, ok bool) {
-	if u, ok := usersMap[userID]; ok {
+func getUserInfo(userID string, users *provider.UsersSnapshot) (userName, realName string, ok bool) {
+	if u, ok := users.Get(userID); ok {
 		return u.Name, u.RealName, true
 	}
 	return userID, userID, false
@@ -590,3 +590,25 @@ func TestUnitLimitByExpression_Invalid(t *testing.T) {
 		})
 	}
 }
+
+func TestUnitIsChannelAllowedByConfig(t *testing.T) {
+	tests := []struct {
+		config  string
+		channel string
+		want    bool
+	}{
+		{"", "C1", true},
+		{"true", "C1", true},
+		{"1", "C1", true},
+		{"C1,D2", "C1", true},
+		{"C1,D2", "C3", false},
+		{"!C1", "C1", false},
+		{"!C1", "C3", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.config+"/"+tt.channel, func(t *testing.T) {
+			assert.Equal(t, tt.want, isChannelAllowedByConfig(tt.channel, tt.config))
+		})
+	}
+}
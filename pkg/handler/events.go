@@ -0,0 +1,119 @@
+package handler
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/korotovsky/slack-mcp-server/pkg/realtime"
+	"github.com/korotovsky/slack-mcp-server/pkg/text"
+	"github.com/mark3labs/mcp-go/mcp"
+	"go.uber.org/zap"
+)
+
+const defaultEventsLimit = 50
+
+type RealtimeEvent struct {
+	Type        string `json:"type"`
+	Subtype     string `json:"subtype"`
+	ChannelID   string `json:"channelID"`
+	ChannelName string `json:"channelName"`
+	UserID      string `json:"userID"`
+	UserName    string `json:"userName"`
+	Ts          string `json:"ts"`
+	ThreadTs    string `json:"threadTs"`
+	Time        string `json:"time"`
+	Text        string `json:"text"`
+	Reaction    string `json:"reaction"`
+}
+
+type EventsHandler struct {
+	apiProvider *provider.ApiProvider
+	ingestor    *realtime.Ingestor
+	logger      *zap.Logger
+}
+
+func NewEventsHandler(apiProvider *provider.ApiProvider, ingestor *realtime.Ingestor, logger *zap.Logger) *EventsHandler {
+	return &EventsHandler{
+		apiProvider: apiProvider,
+		ingestor:    ingestor,
+		logger:      logger,
+	}
+}
+
+// EventsRecentHandler returns events received over the real-time connection,
+// newest first, as CSV
+func (eh *EventsHandler) EventsRecentHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	eh.logger.Debug("EventsRecentHandler called", zap.Any("params", request.Params))
+
+	var channelID string
+	if raw := request.GetString("channel_id", ""); raw != "" {
+		var err error
+		channelID, err = resolveChannelID(ctx, eh.apiProvider, eh.logger, raw)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	types := make(map[string]bool)
+	for _, t := range strings.Split(request.GetString("types", ""), ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			types[t] = true
+		}
+	}
+
+	limit := request.GetInt("limit", defaultEventsLimit)
+	if limit <= 0 {
+		limit = defaultEventsLimit
+	}
+
+	events := eh.ingestor.Buffer().Recent(func(e realtime.Event) bool {
+		if channelID != "" && e.ChannelID != channelID {
+			return false
+		}
+		return len(types) == 0 || types[e.Type]
+	}, limit)
+	eh.logger.Debug("Collected recent events", zap.Int("count", len(events)))
+
+	ids := make([]string, 0, len(events))
+	for _, e := range events {
+		ids = append(ids, e.UserID)
+	}
+	usersMap := eh.apiProvider.ResolveUsers(ctx, ids)
+	channelsMaps := eh.apiProvider.Channels()
+
+	rows := make([]RealtimeEvent, 0, len(events))
+	for _, e := range events {
+		row := RealtimeEvent{
+			Type:      e.Type,
+			Subtype:   e.Subtype,
+			ChannelID: e.ChannelID,
+			UserID:    e.UserID,
+			Ts:        e.Ts,
+			ThreadTs:  e.ThreadTs,
+			Time:      e.ReceivedAt.UTC().Format(time.RFC3339),
+			Text:      text.ProcessText(e.Text),
+			Reaction:  e.Reaction,
+		}
+		if e.Ts != "" {
+			if ts, err := text.TimestampToIsoRFC3339(e.Ts); err == nil {
+				row.Time = ts
+			}
+		}
+		if c, ok := channelsMaps.Get(e.ChannelID); ok {
+			row.ChannelName = c.Name
+		}
+		if u, ok := usersMap.Get(e.UserID); ok {
+			row.UserName = u.Name
+		}
+		rows = append(rows, row)
+	}
+
+	result, err := pageResult(request, rows, "")
+	if err != nil {
+		eh.logger.Error("Failed to marshal events", zap.Error(err))
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gocarina/gocsv"
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/korotovsky/slack-mcp-server/pkg/realtime"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestUnitEventsRecentHandler(t *testing.T) {
+	t.Setenv("SLACK_MCP_XOXP_TOKEN", "demo")
+	p := provider.New("stdio", zap.NewNop())
+	in := realtime.New(p, zap.NewNop())
+
+	received := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	in.Buffer().Add(realtime.Event{Type: "message", ChannelID: "C1", UserID: "U1", Text: "hello", Ts: "1700000000.000100", ReceivedAt: received})
+	in.Buffer().Add(realtime.Event{Type: "reaction_added", ChannelID: "C1", UserID: "U2", Reaction: "eyes", Ts: "1700000000.000100", ReceivedAt: received})
+	in.Buffer().Add(realtime.Event{Type: "channel_deleted", ChannelID: "C2", ReceivedAt: received})
+
+	eh := NewEventsHandler(p, in, zap.NewNop())
+
+	call := func(args map[string]any) []RealtimeEvent {
+		var req mcp.CallToolRequest
+		req.Params.Arguments = args
+		res, err := eh.EventsRecentHandler(context.Background(), req)
+		require.NoError(t, err)
+
+		var rows []RealtimeEvent
+		require.NoError(t, gocsv.UnmarshalString(res.Content[0].(mcp.TextContent).Text, &rows))
+		return rows
+	}
+
+	rows := call(map[string]any{})
+	require.Len(t, rows, 3)
+	assert.Equal(t, "channel_deleted", rows[0].Type)
+	assert.Equal(t, "2024-01-02T03:04:05Z", rows[0].Time)
+
+	rows = call(map[string]any{"channel_id": "C1", "types": "message, reaction_removed"})
+	require.Len(t, rows, 1)
+	assert.Equal(t, "hello", rows[0].Text)
+	assert.Equal(t, "U1", rows[0].UserID)
+
+	rows = call(map[string]any{"limit": 1})
+	require.Len(t, rows, 1)
+	assert.Equal(t, "channel_deleted", rows[0].Type)
+}
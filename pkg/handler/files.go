@@ -0,0 +1,254 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/slack-go/slack"
+	"go.uber.org/zap"
+)
+
+const defaultFilesMaxSize = 5 * 1024 * 1024
+
+type File struct {
+	FileID    string `json:"fileID"`
+	Name      string `json:"name"`
+	Title     string `json:"title"`
+	Mimetype  string `json:"mimetype"`
+	Filetype  string `json:"filetype"`
+	Size      int    `json:"size"`
+	UserID    string `json:"userID"`
+	UserName  string `json:"userName"`
+	Channels  string `json:"channels"`
+	Time      string `json:"time"`
+	Permalink string `json:"permalink"`
+	Cursor    string `json:"-"`
+}
+
+// FilesSearchHandler searches files shared in the workspace using the same
+// query syntax and filters as conversations_search_messages
+func (ch *ConversationsHandler) FilesSearchHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ch.logger.Debug("FilesSearchHandler called", zap.Any("params", request.Params))
+
+	params, err := ch.parseParamsToolSearch(ctx, request)
+	if err != nil {
+		ch.logger.Error("Failed to parse search params", zap.Error(err))
+		return nil, err
+	}
+	ch.logger.Debug("Search params parsed", zap.String("query", params.query), zap.Int("limit", params.limit), zap.Int("page", params.page))
+
+	searchParams := slack.SearchParameters{
+		Sort:          slack.DEFAULT_SEARCH_SORT,
+		SortDirection: slack.DEFAULT_SEARCH_SORT_DIR,
+		Highlight:     false,
+		Count:         params.limit,
+		Page:          params.page,
+	}
+	filesRes, err := ch.apiProvider.Slack().SearchFilesContext(ctx, params.query, searchParams)
+	if err != nil {
+		ch.logger.Error("Slack SearchFilesContext failed", zap.Error(err))
+		return nil, err
+	}
+	ch.logger.Debug("Files search completed", zap.Int("matches", len(filesRes.Matches)))
+
+	files := ch.convertFiles(ctx, filesRes.Matches)
+	var cursor string
+	if len(files) > 0 && ((filesRes.Pagination.PerPage * filesRes.Pagination.PageCount) < filesRes.Pagination.TotalCount) {
+		nextCursor := fmt.Sprintf("page:%d", filesRes.Pagination.PageCount+1)
+		cursor = base64.StdEncoding.EncodeToString([]byte(nextCursor))
+		files[len(files)-1].Cursor = cursor
+	}
+
+	result, err := pageResult(request, files, cursor)
+	if err != nil {
+		ch.logger.Error("Failed to marshal files", zap.Error(err))
+		return nil, err
+	}
+	return result, nil
+}
+
+// FilesGetHandler downloads a file and returns its metadata followed by the
+// content, as text for textual files or as image content for images
+func (ch *ConversationsHandler) FilesGetHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ch.logger.Debug("FilesGetHandler called", zap.Any("params", request.Params))
+
+	fileID := strings.TrimSpace(request.GetString("file_id", ""))
+	if fileID == "" {
+		ch.logger.Error("file_id missing in files_get params")
+		return nil, errors.New("file_id must be a string")
+	}
+
+	maxSize, err := filesMaxSize()
+	if err != nil {
+		ch.logger.Error("Invalid SLACK_MCP_FILES_MAX_SIZE", zap.Error(err))
+		return nil, err
+	}
+
+	file, _, _, err := ch.apiProvider.Slack().GetFileInfoContext(ctx, fileID, 0, 0)
+	if err != nil {
+		ch.logger.Error("Slack GetFileInfoContext failed", zap.String("file_id", fileID), zap.Error(err))
+		return nil, err
+	}
+
+	kind := fileContentKind(file.Mimetype)
+	if kind == "" {
+		ch.logger.Warn("Unsupported file type", zap.String("file_id", fileID), zap.String("mimetype", file.Mimetype))
+		return nil, fmt.Errorf("file %s has unsupported type %q, only text and image files can be retrieved, use its permalink instead: %s", fileID, file.Mimetype, file.Permalink)
+	}
+	if file.Size > maxSize {
+		ch.logger.Warn("File exceeds size cap", zap.String("file_id", fileID), zap.Int("size", file.Size), zap.Int("max_size", maxSize))
+		return nil, fmt.Errorf("file %s is %d bytes which exceeds the limit of %d bytes set by SLACK_MCP_FILES_MAX_SIZE", fileID, file.Size, maxSize)
+	}
+
+	downloadURL := file.URLPrivateDownload
+	if downloadURL == "" {
+		downloadURL = file.URLPrivate
+	}
+
+	buf := &cappedBuffer{max: maxSize}
+	if err := ch.apiProvider.Slack().GetFileContext(ctx, downloadURL, buf); err != nil {
+		ch.logger.Error("Slack GetFileContext failed", zap.String("file_id", fileID), zap.Error(err))
+		return nil, err
+	}
+	data := buf.Bytes()
+	ch.logger.Debug("Downloaded file", zap.String("file_id", fileID), zap.Int("bytes", len(data)))
+
+	if kind == "text" && !utf8.Valid(data) {
+		ch.logger.Warn("File content is not valid UTF-8", zap.String("file_id", fileID))
+		return nil, fmt.Errorf("file %s is not a valid UTF-8 text file", fileID)
+	}
+
+	// A single file is no page, so the structured content is the File
+	// itself rather than a Page.
+	meta := ch.convertFiles(ctx, []slack.File{*file})
+	result, err := toolResult(request, &meta, meta[0])
+	if err != nil {
+		ch.logger.Error("Failed to marshal files", zap.Error(err))
+		return nil, err
+	}
+
+	var content mcp.Content
+	if kind == "image" {
+		content = mcp.NewImageContent(base64.StdEncoding.EncodeToString(data), file.Mimetype)
+	} else {
+		content = mcp.NewTextContent(string(data))
+	}
+
+	result.Content = append(result.Content, content)
+	return result, nil
+}
+
+func (ch *ConversationsHandler) convertFiles(ctx context.Context, slackFiles []slack.File) []File {
+	ids := make([]string, 0, len(slackFiles))
+	for _, f := range slackFiles {
+		ids = append(ids, f.User)
+	}
+	usersMap := ch.apiProvider.ResolveUsers(ctx, ids)
+
+	var files []File
+	for _, f := range slackFiles {
+		userName, _, _ := getUserInfo(f.User, usersMap)
+
+		var channels []string
+		channels = append(channels, f.Channels...)
+		channels = append(channels, f.Groups...)
+		channels = append(channels, f.IMs...)
+
+		files = append(files, File{
+			FileID:    f.ID,
+			Name:      f.Name,
+			Title:     f.Title,
+			Mimetype:  f.Mimetype,
+			Filetype:  f.Filetype,
+			Size:      f.Size,
+			UserID:    f.User,
+			UserName:  userName,
+			Channels:  strings.Join(channels, "|"),
+			Time:      f.Timestamp.Time().UTC().Format(time.RFC3339),
+			Permalink: f.Permalink,
+		})
+	}
+	return files
+}
+
+// formatMessageFiles renders the files attached to a message into a single
+// CSV cell: id:name:mimetype:size:permalink entries separated by |.
+func formatMessageFiles(files []slack.File) string {
+	var parts []string
+	for _, f := range files {
+		parts = append(parts, fmt.Sprintf("%s:%s:%s:%d:%s", f.ID, f.Name, f.Mimetype, f.Size, f.Permalink))
+	}
+	return strings.Join(parts, "|")
+}
+
+// indexFilesByMessage maps "channelID/ts" of every message a file was shared
+// in to the file, so search results can be joined with file matches.
+func indexFilesByMessage(files []slack.File) map[string][]slack.File {
+	index := make(map[string][]slack.File)
+	for _, f := range files {
+		for _, shares := range []map[string][]slack.ShareFileInfo{f.Shares.Public, f.Shares.Private} {
+			for channelID, infos := range shares {
+				for _, info := range infos {
+					key := channelID + "/" + info.Ts
+					index[key] = append(index[key], f)
+				}
+			}
+		}
+	}
+	return index
+}
+
+// fileContentKind returns "text" or "image" for mimetypes files_get can
+// return, or an empty string for unsupported ones.
+func fileContentKind(mimetype string) string {
+	mimetype = strings.ToLower(strings.TrimSpace(strings.SplitN(mimetype, ";", 2)[0]))
+	switch {
+	case strings.HasPrefix(mimetype, "image/"):
+		return "image"
+	case strings.HasPrefix(mimetype, "text/"),
+		mimetype == "application/json",
+		mimetype == "application/xml",
+		mimetype == "application/javascript",
+		mimetype == "application/x-yaml",
+		mimetype == "application/yaml",
+		mimetype == "application/x-sh",
+		mimetype == "application/sql":
+		return "text"
+	}
+	return ""
+}
+
+func filesMaxSize() (int, error) {
+	raw := os.Getenv("SLACK_MCP_FILES_MAX_SIZE")
+	if raw == "" {
+		return defaultFilesMaxSize, nil
+	}
+	size, err := strconv.Atoi(raw)
+	if err != nil || size <= 0 {
+		return 0, fmt.Errorf("SLACK_MCP_FILES_MAX_SIZE must be a positive number of bytes, got %q", raw)
+	}
+	return size, nil
+}
+
+// cappedBuffer guards against files growing past the size cap between the
+// metadata lookup and the download.
+type cappedBuffer struct {
+	bytes.Buffer
+	max int
+}
+
+func (b *cappedBuffer) Write(p []byte) (int, error) {
+	if b.Len()+len(p) > b.max {
+		return 0, fmt.Errorf("file exceeds the limit of %d bytes set by SLACK_MCP_FILES_MAX_SIZE", b.max)
+	}
+	return b.Buffer.Write(p)
+}
@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitIndexFilesByMessage(t *testing.T) {
+	files := []slack.File{
+		{
+			ID:   "F1",
+			Name: "report.pdf",
+			Shares: slack.Share{
+				Public: map[string][]slack.ShareFileInfo{
+					"C1": {{Ts: "1700000000.000100"}, {Ts: "1700000000.000200"}},
+				},
+			},
+		},
+		{
+			ID:   "F2",
+			Name: "diagram.png",
+			Shares: slack.Share{
+				Private: map[string][]slack.ShareFileInfo{
+					"G1": {{Ts: "1700000000.000300"}},
+				},
+				Public: map[string][]slack.ShareFileInfo{
+					"C1": {{Ts: "1700000000.000100"}},
+				},
+			},
+		},
+	}
+
+	index := indexFilesByMessage(files)
+	require.Len(t, index, 3)
+	assert.Len(t, index["C1/1700000000.000100"], 2)
+	assert.Equal(t, "F1", index["C1/1700000000.000200"][0].ID)
+	assert.Equal(t, "F2", index["G1/1700000000.000300"][0].ID)
+	assert.Empty(t, index["C1/1700000000.000300"])
+}
+
+func TestUnitFormatMessageFiles(t *testing.T) {
+	assert.Equal(t, "", formatMessageFiles(nil))
+	assert.Equal(t,
+		"F1:report.pdf:application/pdf:1024:https://x.slack.com/files/U1/F1/report.pdf|F2:a.png:image/png:10:https://x.slack.com/files/U1/F2/a.png",
+		formatMessageFiles([]slack.File{
+			{ID: "F1", Name: "report.pdf", Mimetype: "application/pdf", Size: 1024, Permalink: "https://x.slack.com/files/U1/F1/report.pdf"},
+			{ID: "F2", Name: "a.png", Mimetype: "image/png", Size: 10, Permalink: "https://x.slack.com/files/U1/F2/a.png"},
+		}),
+	)
+}
+
+func TestUnitFileContentKind(t *testing.T) {
+	tests := map[string]string{
+		"image/png":                "image",
+		"image/jpeg":               "image",
+		"text/plain":               "text",
+		"text/csv; charset=utf-8":  "text",
+		"application/json":         "text",
+		"application/pdf":          "",
+		"application/octet-stream": "",
+		"":                         "",
+	}
+	for mimetype, want := range tests {
+		assert.Equal(t, want, fileContentKind(mimetype), mimetype)
+	}
+}
+
+func TestUnitCappedBuffer(t *testing.T) {
+	buf := &cappedBuffer{max: 8}
+	_, err := buf.Write([]byte("12345"))
+	require.NoError(t, err)
+	_, err = buf.Write([]byte("678"))
+	require.NoError(t, err)
+	_, err = buf.Write([]byte("9"))
+	assert.Error(t, err)
+	assert.Equal(t, "12345678", buf.String())
+}
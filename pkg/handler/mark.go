@@ -0,0 +1,164 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/text"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/slack-go/slack"
+	"go.uber.org/zap"
+)
+
+type MarkedConversation struct {
+	ChannelID   string `json:"channelID"`
+	ChannelName string `json:"channelName"`
+	ThreadTs    string `json:"threadTs"`
+	LastRead    string `json:"lastRead"`
+	LastReadAt  string `json:"lastReadAt"`
+}
+
+type markParams struct {
+	channel  string
+	threadTs string
+	ts       string
+}
+
+// ConversationsMarkHandler moves the read cursor of a channel, DM or thread
+// to the given message, or to the latest one when no timestamp is passed
+func (ch *ConversationsHandler) ConversationsMarkHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ch.logger.Debug("ConversationsMarkHandler called", zap.Any("params", request.Params))
+
+	params, err := ch.parseParamsToolMark(ctx, request)
+	if err != nil {
+		ch.logger.Error("Failed to parse conversations_mark params", zap.Error(err))
+		return nil, err
+	}
+
+	if params.ts == "" {
+		params.ts, err = ch.latestTimestamp(ctx, params.channel, params.threadTs)
+		if err != nil {
+			ch.logger.Error("Failed to resolve latest message", zap.String("channel", params.channel), zap.Error(err))
+			return nil, err
+		}
+	}
+
+	ch.logger.Debug("Marking conversation as read",
+		zap.String("channel", params.channel),
+		zap.String("thread_ts", params.threadTs),
+		zap.String("ts", params.ts),
+	)
+	if params.threadTs != "" {
+		err = ch.apiProvider.Slack().SubscriptionsThreadMark(ctx, params.channel, params.threadTs, params.ts)
+		if err != nil {
+			ch.logger.Error("Slack SubscriptionsThreadMark failed", zap.Error(err))
+			return nil, fmt.Errorf("failed to mark thread %s as read, marking threads requires a browser session token (xoxc/xoxd): %w", params.threadTs, err)
+		}
+	} else {
+		err = ch.apiProvider.Slack().MarkConversationContext(ctx, params.channel, params.ts)
+		if err != nil {
+			ch.logger.Error("Slack MarkConversationContext failed", zap.Error(err))
+			return nil, err
+		}
+	}
+
+	name := params.channel
+	if c, ok := ch.apiProvider.Channels().Get(params.channel); ok {
+		name = c.Name
+	}
+	lastReadAt, _ := text.TimestampToIsoRFC3339(params.ts)
+
+	marked := []MarkedConversation{{
+		ChannelID:   params.channel,
+		ChannelName: name,
+		ThreadTs:    params.threadTs,
+		LastRead:    params.ts,
+		LastReadAt:  lastReadAt,
+	}}
+	result, err := pageResult(request, marked, "")
+	if err != nil {
+		ch.logger.Error("Failed to marshal marked conversation", zap.Error(err))
+		return nil, err
+	}
+	return result, nil
+}
+
+// latestTimestamp returns the timestamp of the newest message in the channel,
+// or of the newest reply when threadTs is set.
+func (ch *ConversationsHandler) latestTimestamp(ctx context.Context, channel, threadTs string) (string, error) {
+	if threadTs != "" {
+		replies, _, _, err := ch.apiProvider.Slack().GetConversationRepliesContext(ctx, &slack.GetConversationRepliesParameters{
+			ChannelID: channel,
+			Timestamp: threadTs,
+			Limit:     1,
+		})
+		if err != nil {
+			return "", err
+		}
+		for _, msg := range replies {
+			if msg.Timestamp != threadTs {
+				continue
+			}
+			if msg.LatestReply != "" {
+				return msg.LatestReply, nil
+			}
+			return msg.Timestamp, nil
+		}
+		return "", fmt.Errorf("thread %s not found in channel %s", threadTs, channel)
+	}
+
+	history, err := ch.apiProvider.Slack().GetConversationHistoryContext(ctx, &slack.GetConversationHistoryParameters{
+		ChannelID: channel,
+		Limit:     1,
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(history.Messages) == 0 {
+		return "", fmt.Errorf("channel %s has no messages to mark as read", channel)
+	}
+	return history.Messages[0].Timestamp, nil
+}
+
+func (ch *ConversationsHandler) parseParamsToolMark(ctx context.Context, request mcp.CallToolRequest) (*markParams, error) {
+	toolConfig := os.Getenv("SLACK_MCP_MARK_TOOL")
+	if toolConfig == "" {
+		ch.logger.Error("Mark tool disabled by default")
+		return nil, errors.New(
+			"by default, the conversations_mark tool is disabled to guard the read state of Slack conversations against accidental changes. " +
+				"To enable it, set the SLACK_MCP_MARK_TOOL environment variable to true, 1, or comma separated list of channels " +
+				"to limit which conversations the MCP can mark as read, e.g. 'SLACK_MCP_MARK_TOOL=C1234567890,D0987654321', 'SLACK_MCP_MARK_TOOL=!C1234567890' " +
+				"to enable all except one or 'SLACK_MCP_MARK_TOOL=true' for all channels and DMs",
+		)
+	}
+
+	channel, err := resolveChannelID(ctx, ch.apiProvider, ch.logger, request.GetString("channel_id", ""))
+	if err != nil {
+		return nil, err
+	}
+	if !isChannelAllowedByConfig(channel, toolConfig) {
+		ch.logger.Warn("Mark tool not allowed for channel", zap.String("channel", channel), zap.String("policy", toolConfig))
+		return nil, fmt.Errorf("conversations_mark tool is not allowed for channel %q, applied policy: %s", channel, toolConfig)
+	}
+
+	threadTs := request.GetString("thread_ts", "")
+	if threadTs != "" && !strings.Contains(threadTs, ".") {
+		ch.logger.Error("Invalid thread_ts format", zap.String("thread_ts", threadTs))
+		return nil, errors.New("thread_ts must be a valid timestamp in format 1234567890.123456")
+	}
+
+	ts := request.GetString("ts", "")
+	if ts != "" && !strings.Contains(ts, ".") {
+		ch.logger.Error("Invalid ts format", zap.String("ts", ts))
+		return nil, errors.New("ts must be a valid timestamp in format 1234567890.123456")
+	}
+
+	return &markParams{
+		channel:  channel,
+		threadTs: threadTs,
+		ts:       ts,
+	}, nil
+}
@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestUnitParseParamsToolMark(t *testing.T) {
+	ch := &ConversationsHandler{logger: zap.NewNop()}
+
+	request := func(args map[string]any) mcp.CallToolRequest {
+		var req mcp.CallToolRequest
+		req.Params.Arguments = args
+		return req
+	}
+
+	t.Setenv("SLACK_MCP_MARK_TOOL", "")
+	_, err := ch.parseParamsToolMark(context.Background(), request(map[string]any{"channel_id": "C123"}))
+	assert.ErrorContains(t, err, "SLACK_MCP_MARK_TOOL")
+
+	t.Setenv("SLACK_MCP_MARK_TOOL", "C123")
+	_, err = ch.parseParamsToolMark(context.Background(), request(map[string]any{"channel_id": "C456"}))
+	assert.ErrorContains(t, err, "not allowed for channel")
+
+	params, err := ch.parseParamsToolMark(context.Background(), request(map[string]any{"channel_id": "C123"}))
+	require.NoError(t, err)
+	assert.Equal(t, &markParams{channel: "C123"}, params)
+
+	params, err = ch.parseParamsToolMark(context.Background(), request(map[string]any{
+		"channel_id": "C123",
+		"thread_ts":  "1700000000.000100",
+		"ts":         "1700000100.000200",
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, &markParams{channel: "C123", threadTs: "1700000000.000100", ts: "1700000100.000200"}, params)
+
+	_, err = ch.parseParamsToolMark(context.Background(), request(map[string]any{"channel_id": "C123", "ts": "yesterday"}))
+	assert.Error(t, err)
+
+	_, err = ch.parseParamsToolMark(context.Background(), request(map[string]any{}))
+	assert.Error(t, err)
+}
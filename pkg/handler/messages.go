@@ -0,0 +1,218 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/slack-go/slack"
+	"go.uber.org/zap"
+)
+
+type modifyMessageParams struct {
+	channel     string
+	timestamp   string
+	text        string
+	contentType string
+}
+
+// ConversationsEditMessageHandler replaces the content of a previously posted
+// message and returns the updated message as CSV
+func (ch *ConversationsHandler) ConversationsEditMessageHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ch.logger.Debug("ConversationsEditMessageHandler called", zap.Any("params", request.Params))
+
+	params, err := ch.parseParamsToolModifyMessage(ctx, request, "conversations_edit_message", true)
+	if err != nil {
+		ch.logger.Error("Failed to parse edit-message params", zap.Error(err))
+		return nil, err
+	}
+
+	if _, err := ch.fetchModifiableMessage(ctx, "conversations_edit_message", params.channel, params.timestamp); err != nil {
+		return nil, err
+	}
+
+	options, err := ch.messageContentOptions(params.text, params.contentType)
+	if err != nil {
+		return nil, err
+	}
+
+	ch.logger.Debug("Updating Slack message",
+		zap.String("channel", params.channel),
+		zap.String("ts", params.timestamp),
+		zap.String("content_type", params.contentType),
+	)
+	respChannel, respTimestamp, _, err := ch.apiProvider.Slack().UpdateMessageContext(ctx, params.channel, params.timestamp, options...)
+	if err != nil {
+		ch.logger.Error("Slack UpdateMessageContext failed", zap.Error(err))
+		return nil, err
+	}
+
+	updated, err := ch.fetchMessage(ctx, respChannel, respTimestamp)
+	if err != nil {
+		ch.logger.Error("Failed to fetch updated message", zap.Error(err))
+		return nil, err
+	}
+
+	messages := ch.convertMessagesFromHistory(ctx, []slack.Message{*updated}, respChannel, true)
+	return marshalMessages(request, messages)
+}
+
+// ConversationsDeleteMessageHandler deletes a previously posted message and
+// returns the message as it was before deletion as CSV
+func (ch *ConversationsHandler) ConversationsDeleteMessageHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ch.logger.Debug("ConversationsDeleteMessageHandler called", zap.Any("params", request.Params))
+
+	params, err := ch.parseParamsToolModifyMessage(ctx, request, "conversations_delete_message", false)
+	if err != nil {
+		ch.logger.Error("Failed to parse delete-message params", zap.Error(err))
+		return nil, err
+	}
+
+	msg, err := ch.fetchModifiableMessage(ctx, "conversations_delete_message", params.channel, params.timestamp)
+	if err != nil {
+		return nil, err
+	}
+
+	ch.logger.Debug("Deleting Slack message",
+		zap.String("channel", params.channel),
+		zap.String("ts", params.timestamp),
+	)
+	if _, _, err := ch.apiProvider.Slack().DeleteMessageContext(ctx, params.channel, params.timestamp); err != nil {
+		ch.logger.Error("Slack DeleteMessageContext failed", zap.Error(err))
+		return nil, err
+	}
+
+	messages := ch.convertMessagesFromHistory(ctx, []slack.Message{*msg}, params.channel, true)
+	return marshalMessages(request, messages)
+}
+
+// fetchModifiableMessage loads the target message and, unless
+// SLACK_MCP_EDIT_ANY_MESSAGE is set, makes sure it was authored by the
+// authenticated user.
+func (ch *ConversationsHandler) fetchModifiableMessage(ctx context.Context, tool, channel, timestamp string) (*slack.Message, error) {
+	msg, err := ch.fetchMessage(ctx, channel, timestamp)
+	if err != nil {
+		ch.logger.Error("Failed to fetch message", zap.String("channel", channel), zap.String("ts", timestamp), zap.Error(err))
+		return nil, err
+	}
+
+	editAny := os.Getenv("SLACK_MCP_EDIT_ANY_MESSAGE")
+	if editAny == "1" || editAny == "true" || editAny == "yes" {
+		return msg, nil
+	}
+
+	authResp, err := ch.apiProvider.Slack().AuthTest()
+	if err != nil {
+		ch.logger.Error("Slack AuthTest failed", zap.Error(err))
+		return nil, err
+	}
+	if !isOwnMessage(msg, authResp) {
+		ch.logger.Warn("Refusing to modify message of another user",
+			zap.String("tool", tool),
+			zap.String("channel", channel),
+			zap.String("ts", timestamp),
+			zap.String("author", msg.User),
+		)
+		return nil, fmt.Errorf("%s can only modify messages authored by the authenticated user %s, message %s in %s was posted by %q; set SLACK_MCP_EDIT_ANY_MESSAGE=true to lift this restriction", tool, authResp.UserID, timestamp, channel, msg.User)
+	}
+	return msg, nil
+}
+
+// fetchMessage returns a single message by its timestamp, looking into thread
+// replies when the message is not part of the channel history.
+func (ch *ConversationsHandler) fetchMessage(ctx context.Context, channel, timestamp string) (*slack.Message, error) {
+	history, err := ch.apiProvider.Slack().GetConversationHistoryContext(ctx, &slack.GetConversationHistoryParameters{
+		ChannelID: channel,
+		Limit:     1,
+		Oldest:    timestamp,
+		Latest:    timestamp,
+		Inclusive: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, msg := range history.Messages {
+		if msg.Timestamp == timestamp {
+			return &msg, nil
+		}
+	}
+
+	replies, _, _, err := ch.apiProvider.Slack().GetConversationRepliesContext(ctx, &slack.GetConversationRepliesParameters{
+		ChannelID: channel,
+		Timestamp: timestamp,
+		Limit:     1,
+		Oldest:    timestamp,
+		Latest:    timestamp,
+		Inclusive: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, msg := range replies {
+		if msg.Timestamp == timestamp {
+			return &msg, nil
+		}
+	}
+
+	return nil, fmt.Errorf("message %s not found in channel %s", timestamp, channel)
+}
+
+func (ch *ConversationsHandler) parseParamsToolModifyMessage(ctx context.Context, request mcp.CallToolRequest, tool string, withPayload bool) (*modifyMessageParams, error) {
+	toolConfig := os.Getenv("SLACK_MCP_ADD_MESSAGE_TOOL")
+	if toolConfig == "" {
+		ch.logger.Error("Message tools disabled by default", zap.String("tool", tool))
+		return nil, fmt.Errorf(
+			"by default, the %s tool is disabled to guard Slack workspaces against accidental changes. "+
+				"To enable it, set the SLACK_MCP_ADD_MESSAGE_TOOL environment variable to true, 1, or comma separated list of channels "+
+				"to limit where the MCP can modify messages, e.g. 'SLACK_MCP_ADD_MESSAGE_TOOL=C1234567890,D0987654321', 'SLACK_MCP_ADD_MESSAGE_TOOL=!C1234567890' "+
+				"to enable all except one or 'SLACK_MCP_ADD_MESSAGE_TOOL=true' for all channels and DMs", tool,
+		)
+	}
+
+	channel, err := resolveChannelID(ctx, ch.apiProvider, ch.logger, request.GetString("channel_id", ""))
+	if err != nil {
+		return nil, err
+	}
+	if !isChannelAllowed(channel) {
+		ch.logger.Warn("Message tool not allowed for channel", zap.String("tool", tool), zap.String("channel", channel), zap.String("policy", toolConfig))
+		return nil, fmt.Errorf("%s tool is not allowed for channel %q, applied policy: %s", tool, channel, toolConfig)
+	}
+
+	timestamp := request.GetString("timestamp", "")
+	if timestamp == "" || !strings.Contains(timestamp, ".") {
+		ch.logger.Error("Invalid timestamp format", zap.String("timestamp", timestamp))
+		return nil, errors.New("timestamp must be a valid timestamp in format 1234567890.123456")
+	}
+
+	params := &modifyMessageParams{
+		channel:   channel,
+		timestamp: timestamp,
+	}
+	if !withPayload {
+		return params, nil
+	}
+
+	params.text = request.GetString("payload", "")
+	if params.text == "" {
+		ch.logger.Error("Message text missing")
+		return nil, errors.New("text must be a string")
+	}
+
+	params.contentType = request.GetString("content_type", "text/markdown")
+	if params.contentType != "text/plain" && params.contentType != "text/markdown" {
+		ch.logger.Error("Invalid content_type", zap.String("content_type", params.contentType))
+		return nil, errors.New("content_type must be either 'text/plain' or 'text/markdown'")
+	}
+
+	return params, nil
+}
+
+func isOwnMessage(msg *slack.Message, authResp *slack.AuthTestResponse) bool {
+	if msg.User != "" {
+		return msg.User == authResp.UserID
+	}
+	return authResp.BotID != "" && msg.BotID == authResp.BotID
+}
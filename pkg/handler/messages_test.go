@@ -0,0 +1,22 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnitIsOwnMessage(t *testing.T) {
+	authResp := &slack.AuthTestResponse{UserID: "U1", BotID: "B1"}
+
+	msg := func(user, bot string) *slack.Message {
+		return &slack.Message{Msg: slack.Msg{User: user, BotID: bot}}
+	}
+
+	assert.True(t, isOwnMessage(msg("U1", ""), authResp))
+	assert.False(t, isOwnMessage(msg("U2", ""), authResp))
+	assert.True(t, isOwnMessage(msg("", "B1"), authResp))
+	assert.False(t, isOwnMessage(msg("", "B2"), authResp))
+	assert.False(t, isOwnMessage(msg("", ""), &slack.AuthTestResponse{UserID: "U1"}))
+}
@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"encoding/json"
+
+	"github.com/gocarina/gocsv"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const (
+	OutputFormatCSV  = "csv"
+	OutputFormatJSON = "json"
+)
+
+// Page is the structured content of a tool result: the CSV rows as items,
+// with the pagination cursor of the last row hoisted to the top level.
+type Page[T any] struct {
+	Items   []T    `json:"items"`
+	Cursor  string `json:"cursor,omitempty"`
+	HasMore bool   `json:"has_more"`
+}
+
+func newPage[T any](rows []T, cursor string) Page[T] {
+	if rows == nil {
+		rows = []T{}
+	}
+	return Page[T]{
+		Items:   rows,
+		Cursor:  cursor,
+		HasMore: cursor != "",
+	}
+}
+
+// pageResult renders rows as a tool result, see toolResult.
+func pageResult[T any](request mcp.CallToolRequest, rows []T, cursor string) (*mcp.CallToolResult, error) {
+	return toolResult(request, &rows, newPage(rows, cursor))
+}
+
+func isJSONOutput(request mcp.CallToolRequest) bool {
+	return request.GetString("output_format", OutputFormatCSV) == OutputFormatJSON
+}
+
+// toolResult always carries structured as structured content, since every
+// tool declares an output schema. The text content is the CSV of csvRows, or
+// structured serialized as JSON when output_format is json.
+func toolResult(request mcp.CallToolRequest, csvRows any, structured any) (*mcp.CallToolResult, error) {
+	if isJSONOutput(request) {
+		jsonBytes, err := json.Marshal(structured)
+		if err != nil {
+			return nil, err
+		}
+		return mcp.NewToolResultStructured(structured, string(jsonBytes)), nil
+	}
+
+	csvBytes, err := gocsv.MarshalBytes(csvRows)
+	if err != nil {
+		return nil, err
+	}
+	return mcp.NewToolResultStructured(structured, string(csvBytes)), nil
+}
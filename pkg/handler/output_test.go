@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitPageResult(t *testing.T) {
+	rows := []Channel{
+		{ID: "C1", Name: "#general", MemberCount: 3},
+		{ID: "C2", Name: "#random", MemberCount: 2, Cursor: "next"},
+	}
+	request := func(format string) mcp.CallToolRequest {
+		var req mcp.CallToolRequest
+		if format != "" {
+			req.Params.Arguments = map[string]any{"output_format": format}
+		}
+		return req
+	}
+
+	res, err := pageResult(request(""), rows, "next")
+	require.NoError(t, err)
+	require.Len(t, res.Content, 1)
+	assert.Equal(t, "ID,Name,Topic,Purpose,MemberCount,Cursor\nC1,#general,,,3,\nC2,#random,,,2,next\n", res.Content[0].(mcp.TextContent).Text)
+	assert.Equal(t, Page[Channel]{Items: rows, Cursor: "next", HasMore: true}, res.StructuredContent)
+
+	res, err = pageResult(request(OutputFormatJSON), rows, "next")
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"items": [
+			{"id": "C1", "name": "#general", "topic": "", "purpose": "", "memberCount": 3},
+			{"id": "C2", "name": "#random", "topic": "", "purpose": "", "memberCount": 2}
+		],
+		"cursor": "next",
+		"has_more": true
+	}`, res.Content[0].(mcp.TextContent).Text)
+
+	res, err = pageResult[Channel](request(OutputFormatJSON), nil, "")
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"items": [], "has_more": false}`, res.Content[0].(mcp.TextContent).Text)
+}
@@ -0,0 +1,247 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"go.uber.org/zap"
+)
+
+type PromptsHandler struct {
+	// hasTool tells whether a tool is offered to clients, so that prompts
+	// never send the model to a tool disabled by configuration.
+	hasTool func(name string) bool
+	logger  *zap.Logger
+}
+
+func NewPromptsHandler(hasTool func(name string) bool, logger *zap.Logger) *PromptsHandler {
+	return &PromptsHandler{
+		hasTool: hasTool,
+		logger:  logger,
+	}
+}
+
+// promptTools lists the tools every prompt cannot do without. Other tools a
+// prompt mentions are optional and their steps are left out when disabled.
+var promptTools = map[string][]string{
+	"summarize_channel":  {"conversations_history"},
+	"catch_up_mentions":  {"unreads_list"},
+	"draft_thread_reply": {"conversations_replies"},
+	"standup_digest":     {"conversations_history"},
+}
+
+// Available tells whether the tools the prompt relies on are enabled.
+func (ph *PromptsHandler) Available(prompt string) bool {
+	for _, tool := range promptTools[prompt] {
+		if !ph.hasTool(tool) {
+			return false
+		}
+	}
+	return true
+}
+
+// promptStep is an instruction that calls tool, or no tool when empty.
+type promptStep struct {
+	tool string
+	text string
+}
+
+// steps numbers the steps whose tool is enabled.
+func (ph *PromptsHandler) steps(steps ...promptStep) string {
+	var b strings.Builder
+	n := 0
+	for _, step := range steps {
+		if step.tool != "" && !ph.hasTool(step.tool) {
+			continue
+		}
+		n++
+		fmt.Fprintf(&b, "%d. %s\n", n, step.text)
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// SummarizeChannelPrompt expands into instructions to summarize a channel
+// over a period of time.
+func (ph *PromptsHandler) SummarizeChannelPrompt(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	ph.logger.Debug("SummarizeChannelPrompt called", zap.Any("params", request.Params))
+
+	channel, err := promptChannel(request.Params.Arguments["channel"])
+	if err != nil {
+		return nil, err
+	}
+	period, err := promptPeriod(request.Params.Arguments["period"], "7d")
+	if err != nil {
+		return nil, err
+	}
+
+	return promptResult(
+		fmt.Sprintf("Summary of %s for the last %s", channel, period),
+		fmt.Sprintf(`Summarize what happened in the Slack channel %[1]s over the last %[2]s.
+
+%[3]s
+
+Write the summary with these sections:
+- Key topics and decisions, each with a link to the message.
+- Open questions that did not get an answer.
+- Action items with their owners and due dates, if mentioned.
+
+Keep it short and skip small talk, join/leave messages and bot noise.`, channel, period, ph.steps(
+			promptStep{"conversations_history", fmt.Sprintf("Call conversations_history with channel_id=%q and limit=%q. Keep paginating with the cursor from the last row until it is empty.", channel, period)},
+			promptStep{"conversations_replies", "For messages with replies worth reading, call conversations_replies with the same channel_id and the message timestamp as thread_ts."},
+			promptStep{"users_info", "If user IDs are not resolved to names, look them up with users_info."},
+		)),
+	), nil
+}
+
+// CatchUpMentionsPrompt expands into instructions to go through unread
+// mentions and direct messages.
+func (ph *PromptsHandler) CatchUpMentionsPrompt(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	ph.logger.Debug("CatchUpMentionsPrompt called", zap.Any("params", request.Params))
+
+	period, err := promptPeriod(request.Params.Arguments["period"], "1d")
+	if err != nil {
+		return nil, err
+	}
+	markRead := strings.EqualFold(strings.TrimSpace(request.Params.Arguments["mark_read"]), "true")
+
+	steps := fmt.Sprintf(`Catch me up on everything in Slack that needs my attention from the last %[1]s.
+
+%[2]s
+
+Group the result by urgency:
+- Needs a reply from me, with a one-line suggestion of what to answer.
+- FYI, no action needed.
+
+Refer to people by their names and to channels by their #names.`, period, ph.steps(
+		promptStep{"unreads_list", "Call unreads_list with mentions_only=true and include_messages=true to get conversations where I was mentioned, with the unread messages."},
+		promptStep{"unreads_list", `Call unreads_list with channel_types="im,mpim" and include_messages=true to get unread direct messages.`},
+		promptStep{"conversations_replies", "For mentions inside threads, call conversations_replies with the channel_id and thread_ts to get the full context."},
+		promptStep{"", fmt.Sprintf("Ignore anything older than %s.", period)},
+	))
+	if markRead && ph.hasTool("conversations_mark") {
+		steps += "\n\nWhen done, call conversations_mark for each conversation you went through so they no longer show up as unread."
+	} else {
+		steps += "\n\nDo not mark anything as read."
+	}
+
+	return promptResult(fmt.Sprintf("Mentions and DMs from the last %s", period), steps), nil
+}
+
+// DraftThreadReplyPrompt expands into instructions to draft, but not send, a
+// reply to a thread.
+func (ph *PromptsHandler) DraftThreadReplyPrompt(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	ph.logger.Debug("DraftThreadReplyPrompt called", zap.Any("params", request.Params))
+
+	channel, err := promptChannel(request.Params.Arguments["channel"])
+	if err != nil {
+		return nil, err
+	}
+	threadTs := strings.TrimSpace(request.Params.Arguments["thread_ts"])
+	if threadTs == "" || !strings.Contains(threadTs, ".") {
+		return nil, errors.New("thread_ts must be a valid timestamp in format 1234567890.123456")
+	}
+
+	intent := strings.TrimSpace(request.Params.Arguments["intent"])
+	if intent == "" {
+		intent = "answer the open questions addressed to me"
+	}
+	tone := strings.TrimSpace(request.Params.Arguments["tone"])
+	if tone == "" {
+		tone = "friendly and concise"
+	}
+
+	post := fmt.Sprintf("Show me the draft first and do not post it. Only after I confirm, call conversations_add_message with channel_id=%q, thread_ts=%q and the approved text as payload.", channel, threadTs)
+	if !ph.hasTool("conversations_add_message") {
+		post = "Show me the draft and do not post it, posting messages is disabled on this server."
+	}
+
+	return promptResult(
+		fmt.Sprintf("Draft reply to thread %s in %s", threadTs, channel),
+		fmt.Sprintf(`Draft a reply to a Slack thread on my behalf.
+
+%[3]s
+
+The reply should %[1]s. Keep the tone %[2]s and use Slack markdown.
+
+%[4]s`, intent, tone, ph.steps(
+			promptStep{"conversations_replies", fmt.Sprintf("Call conversations_replies with channel_id=%q and thread_ts=%q to read the whole thread.", channel, threadTs)},
+			promptStep{"users_info", "If it helps to understand who is asking, look the participants up with users_info."},
+		), post),
+	), nil
+}
+
+// StandupDigestPrompt expands into instructions to build a standup digest
+// from one or more channels.
+func (ph *PromptsHandler) StandupDigestPrompt(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	ph.logger.Debug("StandupDigestPrompt called", zap.Any("params", request.Params))
+
+	var channels []string
+	for _, c := range strings.Split(request.Params.Arguments["channels"], ",") {
+		c = strings.TrimSpace(c)
+		if c == "" {
+			continue
+		}
+		channel, err := promptChannel(c)
+		if err != nil {
+			return nil, err
+		}
+		channels = append(channels, channel)
+	}
+	if len(channels) == 0 {
+		return nil, errors.New("channels must be a comma-separated list of channel IDs or #names")
+	}
+	period, err := promptPeriod(request.Params.Arguments["period"], "1w")
+	if err != nil {
+		return nil, err
+	}
+
+	return promptResult(
+		fmt.Sprintf("Standup digest of %s for the last %s", strings.Join(channels, ", "), period),
+		fmt.Sprintf(`Prepare a standup digest for the last %[2]s from these Slack channels: %[1]s.
+
+%[3]s
+
+Group the digest by person, and for each of them list:
+- Done: what they shipped or finished.
+- In progress: what they are working on.
+- Blockers: anything they are waiting on, with who can unblock it.
+
+Finish with a short list of cross-team risks and decisions. Link to the source messages.`, strings.Join(channels, ", "), period, ph.steps(
+			promptStep{"conversations_history", fmt.Sprintf("For each channel, call conversations_history with the channel as channel_id and limit=%q. Keep paginating with the cursor from the last row until it is empty.", period)},
+			promptStep{"conversations_replies", "Read threads that carry status updates or discussions with conversations_replies."},
+			promptStep{"users_info", "Resolve user IDs to names with users_info when needed."},
+		)),
+	), nil
+}
+
+func promptResult(description, text string) *mcp.GetPromptResult {
+	return mcp.NewGetPromptResult(description, []mcp.PromptMessage{
+		mcp.NewPromptMessage(mcp.RoleUser, mcp.NewTextContent(text)),
+	})
+}
+
+// promptChannel accepts channel IDs and #/@ names, prompts are expanded
+// before any tool call so names are not resolved here.
+func promptChannel(raw string) (string, error) {
+	channel := strings.TrimSpace(raw)
+	if channel == "" {
+		return "", errors.New("channel must be a channel ID or its name starting with # or @, e.g. #general")
+	}
+	return channel, nil
+}
+
+// promptPeriod validates a period in the same format as the limit parameter
+// of conversations_history, e.g. 1d, 2w or 1m.
+func promptPeriod(raw, defaultPeriod string) (string, error) {
+	period := strings.TrimSpace(raw)
+	if period == "" {
+		period = defaultPeriod
+	}
+	if _, _, _, err := limitByExpression(period, defaultPeriod); err != nil {
+		return "", err
+	}
+	return period, nil
+}
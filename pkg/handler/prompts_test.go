@@ -0,0 +1,103 @@
+package handler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestUnitPrompts(t *testing.T) {
+	ph := NewPromptsHandler(func(string) bool { return true }, zap.NewNop())
+
+	request := func(args map[string]string) mcp.GetPromptRequest {
+		var req mcp.GetPromptRequest
+		req.Params.Arguments = args
+		return req
+	}
+	promptText := func(t *testing.T, res *mcp.GetPromptResult) string {
+		require.Len(t, res.Messages, 1)
+		assert.Equal(t, mcp.RoleUser, res.Messages[0].Role)
+		tc, ok := res.Messages[0].Content.(mcp.TextContent)
+		require.True(t, ok)
+		return tc.Text
+	}
+
+	t.Run("summarize_channel", func(t *testing.T) {
+		res, err := ph.SummarizeChannelPrompt(context.Background(), request(map[string]string{"channel": "#general"}))
+		require.NoError(t, err)
+		text := promptText(t, res)
+		assert.Contains(t, text, `channel_id="#general"`)
+		assert.Contains(t, text, `limit="7d"`)
+
+		_, err = ph.SummarizeChannelPrompt(context.Background(), request(map[string]string{}))
+		assert.Error(t, err)
+
+		_, err = ph.SummarizeChannelPrompt(context.Background(), request(map[string]string{"channel": "#general", "period": "forever"}))
+		assert.Error(t, err)
+	})
+
+	t.Run("catch_up_mentions", func(t *testing.T) {
+		res, err := ph.CatchUpMentionsPrompt(context.Background(), request(map[string]string{}))
+		require.NoError(t, err)
+		text := promptText(t, res)
+		assert.Contains(t, text, "unreads_list")
+		assert.NotContains(t, text, "call conversations_mark")
+
+		res, err = ph.CatchUpMentionsPrompt(context.Background(), request(map[string]string{"mark_read": "true"}))
+		require.NoError(t, err)
+		assert.Contains(t, promptText(t, res), "call conversations_mark")
+	})
+
+	t.Run("draft_thread_reply", func(t *testing.T) {
+		res, err := ph.DraftThreadReplyPrompt(context.Background(), request(map[string]string{"channel": "C1", "thread_ts": "1700000000.000100"}))
+		require.NoError(t, err)
+		assert.Contains(t, promptText(t, res), `thread_ts="1700000000.000100"`)
+
+		_, err = ph.DraftThreadReplyPrompt(context.Background(), request(map[string]string{"channel": "C1"}))
+		assert.Error(t, err)
+	})
+
+	t.Run("standup_digest", func(t *testing.T) {
+		res, err := ph.StandupDigestPrompt(context.Background(), request(map[string]string{"channels": "#backend, #frontend,"}))
+		require.NoError(t, err)
+		assert.Contains(t, promptText(t, res), "#backend, #frontend")
+
+		_, err = ph.StandupDigestPrompt(context.Background(), request(map[string]string{"channels": " , "}))
+		assert.Error(t, err)
+	})
+}
+
+func TestUnitPromptsDisabledTools(t *testing.T) {
+	// The read-only preset hides the tools that change state.
+	disabled := map[string]bool{"conversations_add_message": true, "conversations_mark": true, "users_info": true}
+	ph := NewPromptsHandler(func(name string) bool { return !disabled[name] }, zap.NewNop())
+
+	for prompt := range promptTools {
+		assert.True(t, ph.Available(prompt), prompt)
+	}
+	disabled["conversations_history"] = true
+	assert.False(t, ph.Available("summarize_channel"))
+	assert.False(t, ph.Available("standup_digest"))
+	assert.True(t, ph.Available("catch_up_mentions"))
+
+	var req mcp.GetPromptRequest
+	req.Params.Arguments = map[string]string{"channel": "C1", "thread_ts": "1700000000.000100"}
+	res, err := ph.DraftThreadReplyPrompt(context.Background(), req)
+	require.NoError(t, err)
+	text := res.Messages[0].Content.(mcp.TextContent).Text
+	assert.NotContains(t, text, "conversations_add_message")
+	assert.NotContains(t, text, "users_info")
+	assert.Contains(t, text, "1. Call conversations_replies")
+	assert.NotContains(t, text, "2. ")
+
+	req.Params.Arguments = map[string]string{"mark_read": "true"}
+	res, err = ph.CatchUpMentionsPrompt(context.Background(), req)
+	require.NoError(t, err)
+	text = res.Messages[0].Content.(mcp.TextContent).Text
+	assert.NotContains(t, text, "conversations_mark")
+	assert.Contains(t, text, "4. Ignore anything older than 1d.")
+}
@@ -0,0 +1,162 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/slack-go/slack"
+	"go.uber.org/zap"
+)
+
+type Reaction struct {
+	Emoji    string `json:"emoji"`
+	Count    int    `json:"count"`
+	UserID   string `json:"userID"`
+	UserName string `json:"userName"`
+	RealName string `json:"realName"`
+}
+
+type reactionParams struct {
+	channel   string
+	timestamp string
+	emoji     string
+}
+
+// ReactionsAddHandler adds an emoji reaction to a message and returns the
+// resulting reactions as CSV
+func (ch *ConversationsHandler) ReactionsAddHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ch.logger.Debug("ReactionsAddHandler called", zap.Any("params", request.Params))
+
+	params, err := ch.parseParamsToolReaction(ctx, request, "reactions_add", true)
+	if err != nil {
+		ch.logger.Error("Failed to parse reactions_add params", zap.Error(err))
+		return nil, err
+	}
+
+	item := slack.NewRefToMessage(params.channel, params.timestamp)
+	if err := ch.apiProvider.Slack().AddReactionContext(ctx, params.emoji, item); err != nil {
+		ch.logger.Error("Slack AddReactionContext failed", zap.Error(err))
+		return nil, err
+	}
+
+	return ch.reactionsResult(ctx, request, item)
+}
+
+// ReactionsRemoveHandler removes an emoji reaction of the current user from a
+// message and returns the remaining reactions as CSV
+func (ch *ConversationsHandler) ReactionsRemoveHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ch.logger.Debug("ReactionsRemoveHandler called", zap.Any("params", request.Params))
+
+	params, err := ch.parseParamsToolReaction(ctx, request, "reactions_remove", true)
+	if err != nil {
+		ch.logger.Error("Failed to parse reactions_remove params", zap.Error(err))
+		return nil, err
+	}
+
+	item := slack.NewRefToMessage(params.channel, params.timestamp)
+	if err := ch.apiProvider.Slack().RemoveReactionContext(ctx, params.emoji, item); err != nil {
+		ch.logger.Error("Slack RemoveReactionContext failed", zap.Error(err))
+		return nil, err
+	}
+
+	return ch.reactionsResult(ctx, request, item)
+}
+
+// ReactionsGetHandler lists reactions on a message, one row per reacting user
+func (ch *ConversationsHandler) ReactionsGetHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ch.logger.Debug("ReactionsGetHandler called", zap.Any("params", request.Params))
+
+	params, err := ch.parseParamsToolReaction(ctx, request, "reactions_get", false)
+	if err != nil {
+		ch.logger.Error("Failed to parse reactions_get params", zap.Error(err))
+		return nil, err
+	}
+
+	return ch.reactionsResult(ctx, request, slack.NewRefToMessage(params.channel, params.timestamp))
+}
+
+func (ch *ConversationsHandler) reactionsResult(ctx context.Context, request mcp.CallToolRequest, item slack.ItemRef) (*mcp.CallToolResult, error) {
+	itemReactions, err := ch.apiProvider.Slack().GetReactionsContext(ctx, item, slack.GetReactionsParameters{Full: true})
+	if err != nil {
+		ch.logger.Error("Slack GetReactionsContext failed", zap.Error(err))
+		return nil, err
+	}
+	ch.logger.Debug("Fetched reactions", zap.Int("count", len(itemReactions)))
+
+	var ids []string
+	for _, r := range itemReactions {
+		ids = append(ids, r.Users...)
+	}
+	reactions := reactionRows(itemReactions, ch.apiProvider.ResolveUsers(ctx, ids))
+
+	result, err := pageResult(request, reactions, "")
+	if err != nil {
+		ch.logger.Error("Failed to marshal reactions", zap.Error(err))
+		return nil, err
+	}
+	return result, nil
+}
+
+// reactionRows flattens reactions into one row per reacting user, with the
+// user names from users, or the user ID when the user is unknown.
+func reactionRows(itemReactions []slack.ItemReaction, users *provider.UsersSnapshot) []Reaction {
+	var reactions []Reaction
+	for _, r := range itemReactions {
+		for _, uid := range r.Users {
+			userName, realName, _ := getUserInfo(uid, users)
+			reactions = append(reactions, Reaction{
+				Emoji:    r.Name,
+				Count:    r.Count,
+				UserID:   uid,
+				UserName: userName,
+				RealName: realName,
+			})
+		}
+	}
+	return reactions
+}
+
+func (ch *ConversationsHandler) parseParamsToolReaction(ctx context.Context, request mcp.CallToolRequest, tool string, write bool) (*reactionParams, error) {
+	toolConfig := os.Getenv("SLACK_MCP_REACTION_TOOL")
+	if write && toolConfig == "" {
+		ch.logger.Error("Reaction tools disabled by default", zap.String("tool", tool))
+		return nil, fmt.Errorf(
+			"by default, the %s tool is disabled to guard Slack workspaces against accidental reactions. "+
+				"To enable it, set the SLACK_MCP_REACTION_TOOL environment variable to true, 1, or comma separated list of channels "+
+				"to limit where the MCP can react to messages, e.g. 'SLACK_MCP_REACTION_TOOL=C1234567890,D0987654321', 'SLACK_MCP_REACTION_TOOL=!C1234567890' "+
+				"to enable all except one or 'SLACK_MCP_REACTION_TOOL=true' for all channels and DMs", tool,
+		)
+	}
+
+	channel, err := resolveChannelID(ctx, ch.apiProvider, ch.logger, request.GetString("channel_id", ""))
+	if err != nil {
+		return nil, err
+	}
+	if write && !isChannelAllowedByConfig(channel, toolConfig) {
+		ch.logger.Warn("Reaction tool not allowed for channel", zap.String("tool", tool), zap.String("channel", channel), zap.String("policy", toolConfig))
+		return nil, fmt.Errorf("%s tool is not allowed for channel %q, applied policy: %s", tool, channel, toolConfig)
+	}
+
+	timestamp := request.GetString("timestamp", "")
+	if timestamp == "" || !strings.Contains(timestamp, ".") {
+		ch.logger.Error("Invalid timestamp format", zap.String("timestamp", timestamp))
+		return nil, errors.New("timestamp must be a valid timestamp in format 1234567890.123456")
+	}
+
+	emoji := strings.Trim(strings.TrimSpace(request.GetString("emoji", "")), ":")
+	if write && emoji == "" {
+		ch.logger.Error("Emoji missing in reaction params")
+		return nil, errors.New("emoji must be a non-empty emoji name, e.g. 'thumbsup' or 'white_check_mark'")
+	}
+
+	return &reactionParams{
+		channel:   channel,
+		timestamp: timestamp,
+		emoji:     emoji,
+	}, nil
+}
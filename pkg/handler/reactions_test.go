@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestUnitParseParamsToolReaction(t *testing.T) {
+	ch := &ConversationsHandler{logger: zap.NewNop()}
+
+	request := func(args map[string]any) mcp.CallToolRequest {
+		var req mcp.CallToolRequest
+		req.Params.Arguments = args
+		return req
+	}
+	valid := func(channel string) map[string]any {
+		return map[string]any{"channel_id": channel, "timestamp": "1700000000.000100", "emoji": " :+1: "}
+	}
+
+	t.Run("disabled by default", func(t *testing.T) {
+		t.Setenv("SLACK_MCP_REACTION_TOOL", "")
+
+		_, err := ch.parseParamsToolReaction(context.Background(), request(valid("C1")), "reactions_add", true)
+		assert.ErrorContains(t, err, "SLACK_MCP_REACTION_TOOL")
+
+		// Reading reactions needs no opt-in.
+		params, err := ch.parseParamsToolReaction(context.Background(), request(valid("C1")), "reactions_get", false)
+		require.NoError(t, err)
+		assert.Equal(t, &reactionParams{channel: "C1", timestamp: "1700000000.000100", emoji: "+1"}, params)
+	})
+
+	t.Run("allowlist", func(t *testing.T) {
+		t.Setenv("SLACK_MCP_REACTION_TOOL", "C1,D2")
+
+		params, err := ch.parseParamsToolReaction(context.Background(), request(valid("C1")), "reactions_add", true)
+		require.NoError(t, err)
+		assert.Equal(t, "+1", params.emoji)
+
+		_, err = ch.parseParamsToolReaction(context.Background(), request(valid("C3")), "reactions_add", true)
+		assert.ErrorContains(t, err, "not allowed for channel")
+	})
+
+	t.Run("denylist", func(t *testing.T) {
+		t.Setenv("SLACK_MCP_REACTION_TOOL", "!C1")
+
+		_, err := ch.parseParamsToolReaction(context.Background(), request(valid("C1")), "reactions_remove", true)
+		assert.ErrorContains(t, err, "not allowed for channel")
+
+		_, err = ch.parseParamsToolReaction(context.Background(), request(valid("C3")), "reactions_remove", true)
+		assert.NoError(t, err)
+	})
+
+	t.Run("invalid params", func(t *testing.T) {
+		t.Setenv("SLACK_MCP_REACTION_TOOL", "true")
+
+		_, err := ch.parseParamsToolReaction(context.Background(), request(map[string]any{"channel_id": "C1", "emoji": "+1"}), "reactions_add", true)
+		assert.ErrorContains(t, err, "timestamp")
+
+		_, err = ch.parseParamsToolReaction(context.Background(), request(map[string]any{"channel_id": "C1", "timestamp": "1700000000.000100", "emoji": "::"}), "reactions_add", true)
+		assert.ErrorContains(t, err, "emoji")
+	})
+}
+
+func TestUnitReactionRows(t *testing.T) {
+	users := provider.NewUsersSnapshot(testUsers())
+
+	rows := reactionRows([]slack.ItemReaction{
+		{Name: "+1", Count: 2, Users: []string{"U1", "U9"}},
+		{Name: "eyes", Count: 1, Users: []string{"U3"}},
+	}, users)
+
+	assert.Equal(t, []Reaction{
+		{Emoji: "+1", Count: 2, UserID: "U1", UserName: "jane", RealName: "Jane Doe"},
+		{Emoji: "+1", Count: 2, UserID: "U9", UserName: "U9", RealName: "U9"},
+		{Emoji: "eyes", Count: 1, UserID: "U3", UserName: "bob", RealName: "Bob Payne"},
+	}, rows)
+}
@@ -0,0 +1,136 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/server/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"go.uber.org/zap"
+)
+
+// ChannelHistoryResource serves slack://<workspace>/channels/{channel_id}/history
+// by delegating to the conversations_history tool.
+func (ch *ConversationsHandler) ChannelHistoryResource(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	ch.logger.Debug("ChannelHistoryResource called", zap.Any("params", request.Params))
+
+	// mark3labs/mcp-go does not support middlewares for resources.
+	if authenticated, err := auth.IsAuthenticated(ctx, ch.apiProvider.ServerTransport(), ch.logger); !authenticated {
+		ch.logger.Error("Authentication failed for channel history resource", zap.Error(err))
+		return nil, err
+	}
+
+	channel, err := resourceArgument(request, "channel_id")
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := ch.ConversationsHistoryHandler(ctx, toolRequestFromResource(map[string]any{
+		"channel_id": channel,
+	}))
+	if err != nil {
+		return nil, err
+	}
+	return toolResultToResource(request.Params.URI, result)
+}
+
+// ChannelThreadResource serves slack://<workspace>/channels/{channel_id}/threads/{thread_ts}
+// by delegating to the conversations_replies tool.
+func (ch *ConversationsHandler) ChannelThreadResource(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	ch.logger.Debug("ChannelThreadResource called", zap.Any("params", request.Params))
+
+	if authenticated, err := auth.IsAuthenticated(ctx, ch.apiProvider.ServerTransport(), ch.logger); !authenticated {
+		ch.logger.Error("Authentication failed for channel thread resource", zap.Error(err))
+		return nil, err
+	}
+
+	channel, err := resourceArgument(request, "channel_id")
+	if err != nil {
+		return nil, err
+	}
+	threadTs, err := resourceArgument(request, "thread_ts")
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := ch.ConversationsRepliesHandler(ctx, toolRequestFromResource(map[string]any{
+		"channel_id": channel,
+		"thread_ts":  threadTs,
+	}))
+	if err != nil {
+		return nil, err
+	}
+	return toolResultToResource(request.Params.URI, result)
+}
+
+// UserResource serves slack://<workspace>/users/{user_id} by delegating to
+// the users_info tool.
+func (uh *UsersHandler) UserResource(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	uh.logger.Debug("UserResource called", zap.Any("params", request.Params))
+
+	if authenticated, err := auth.IsAuthenticated(ctx, uh.apiProvider.ServerTransport(), uh.logger); !authenticated {
+		uh.logger.Error("Authentication failed for user resource", zap.Error(err))
+		return nil, err
+	}
+
+	user, err := resourceArgument(request, "user_id")
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := uh.UsersInfoHandler(ctx, toolRequestFromResource(map[string]any{
+		"users": user,
+	}))
+	if err != nil {
+		return nil, err
+	}
+	return toolResultToResource(request.Params.URI, result)
+}
+
+// resourceArgument returns a single URI template variable, decoded so that
+// names like %23general can be used in place of IDs.
+func resourceArgument(request mcp.ReadResourceRequest, name string) (string, error) {
+	var raw string
+	switch v := request.Params.Arguments[name].(type) {
+	case string:
+		raw = v
+	case []string:
+		raw = strings.Join(v, ",")
+	}
+	if raw == "" {
+		return "", fmt.Errorf("%s missing in resource URI %q", name, request.Params.URI)
+	}
+
+	value, err := url.PathUnescape(raw)
+	if err != nil {
+		return "", fmt.Errorf("invalid %s in resource URI %q: %w", name, request.Params.URI, err)
+	}
+	return value, nil
+}
+
+func toolRequestFromResource(args map[string]any) mcp.CallToolRequest {
+	var request mcp.CallToolRequest
+	request.Params.Arguments = args
+	return request
+}
+
+func toolResultToResource(uri string, result *mcp.CallToolResult) ([]mcp.ResourceContents, error) {
+	var contents []mcp.ResourceContents
+	for _, c := range result.Content {
+		tc, ok := c.(mcp.TextContent)
+		if !ok {
+			continue
+		}
+		if result.IsError {
+			return nil, fmt.Errorf("failed to read resource %q: %s", uri, tc.Text)
+		}
+		contents = append(contents, mcp.TextResourceContents{
+			URI:      uri,
+			MIMEType: "text/csv",
+			Text:     tc.Text,
+		})
+	}
+	return contents, nil
+}
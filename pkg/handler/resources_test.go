@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitResourceArgument(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		uri      string
+		arg      string
+		expected string
+	}{
+		{"channel ID", "slack://ws/channels/{channel_id}/history", "slack://ws/channels/C1234567890/history", "channel_id", "C1234567890"},
+		{"channel name", "slack://ws/channels/{channel_id}/history", "slack://ws/channels/%23general/history", "channel_id", "#general"},
+		{"thread ts", "slack://ws/channels/{channel_id}/threads/{thread_ts}", "slack://ws/channels/C1/threads/1700000000.000100", "thread_ts", "1700000000.000100"},
+		{"user handle", "slack://ws/users/{user_id}", "slack://ws/users/%40jane", "user_id", "@jane"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl := mcp.NewResourceTemplate(tt.template, tt.name)
+			require.True(t, tmpl.URITemplate.Regexp().MatchString(tt.uri))
+
+			var request mcp.ReadResourceRequest
+			request.Params.URI = tt.uri
+			request.Params.Arguments = map[string]any{}
+			for name, value := range tmpl.URITemplate.Match(tt.uri) {
+				request.Params.Arguments[name] = value.V
+			}
+
+			got, err := resourceArgument(request, tt.arg)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+
+	var request mcp.ReadResourceRequest
+	request.Params.URI = "slack://ws/users/"
+	_, err := resourceArgument(request, "user_id")
+	assert.Error(t, err)
+}
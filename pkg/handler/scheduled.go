@@ -0,0 +1,346 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	// Embedded zoneinfo, the production image and npm binaries ship without it.
+	_ "time/tzdata"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/slack-go/slack"
+	"go.uber.org/zap"
+)
+
+// Slack refuses to schedule messages further than 120 days ahead.
+const maxScheduleAhead = 120 * 24 * time.Hour
+
+var (
+	scheduleInRe   = regexp.MustCompile(`^in\s+(\d+)\s*(minutes?|mins?|m|hours?|hrs?|h|days?|d)$`)
+	scheduleTimeRe = regexp.MustCompile(`(?:^|\s)(?:at\s+)?(\d{1,2})(?::(\d{2}))?\s*(am|pm)(?:\s|$)|(?:^|\s)(?:at\s+)?(\d{1,2}):(\d{2})(?:\s|$)`)
+)
+
+type ScheduledMessage struct {
+	ScheduledMessageID string `json:"scheduledMessageID"`
+	Channel            string `json:"channelID"`
+	PostAt             string `json:"postAt"`
+	DateCreated        string `json:"dateCreated"`
+	Text               string `json:"text"`
+	Cursor             string `json:"-"`
+}
+
+// ConversationsScheduleMessageHandler schedules a message for later delivery
+// and returns the scheduled message as CSV
+func (ch *ConversationsHandler) ConversationsScheduleMessageHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ch.logger.Debug("ConversationsScheduleMessageHandler called", zap.Any("params", request.Params))
+
+	params, err := ch.parseParamsToolAddMessage(ctx, request, "conversations_schedule_message")
+	if err != nil {
+		ch.logger.Error("Failed to parse schedule-message params", zap.Error(err))
+		return nil, err
+	}
+
+	loc, err := ch.scheduleLocation(request.GetString("timezone", ""))
+	if err != nil {
+		ch.logger.Error("Invalid timezone", zap.Error(err))
+		return nil, err
+	}
+
+	now := time.Now()
+	postAt, err := parseScheduleTime(request.GetString("post_at", ""), now, loc)
+	if err != nil {
+		ch.logger.Error("Invalid post_at", zap.Error(err))
+		return nil, err
+	}
+	if !postAt.After(now) {
+		return nil, fmt.Errorf("post_at must be in the future, got %s", postAt.Format(time.RFC3339))
+	}
+	if postAt.Sub(now) > maxScheduleAhead {
+		return nil, fmt.Errorf("post_at must be within 120 days from now, got %s", postAt.Format(time.RFC3339))
+	}
+
+	var options []slack.MsgOption
+	if params.threadTs != "" {
+		options = append(options, slack.MsgOptionTS(params.threadTs))
+	}
+
+	contentOptions, err := ch.messageContentOptions(params.text, params.contentType)
+	if err != nil {
+		return nil, err
+	}
+	options = append(options, contentOptions...)
+	options = append(options, ch.unfurlOptions(params.text)...)
+
+	ch.logger.Debug("Scheduling Slack message",
+		zap.String("channel", params.channel),
+		zap.String("thread_ts", params.threadTs),
+		zap.Time("post_at", postAt),
+		zap.String("content_type", params.contentType),
+	)
+	respChannel, scheduledID, err := ch.apiProvider.Slack().ScheduleMessageContext(ctx, params.channel, strconv.FormatInt(postAt.Unix(), 10), options...)
+	if err != nil {
+		ch.logger.Error("Slack ScheduleMessageContext failed", zap.Error(err))
+		return nil, err
+	}
+
+	scheduled := []ScheduledMessage{{
+		ScheduledMessageID: scheduledID,
+		Channel:            respChannel,
+		PostAt:             postAt.UTC().Format(time.RFC3339),
+		DateCreated:        now.UTC().Format(time.RFC3339),
+		Text:               params.text,
+	}}
+	return pageResult(request, scheduled, "")
+}
+
+// ConversationsListScheduledMessagesHandler lists pending scheduled messages
+// in channels allowed by the write-tool policy
+func (ch *ConversationsHandler) ConversationsListScheduledMessagesHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ch.logger.Debug("ConversationsListScheduledMessagesHandler called", zap.Any("params", request.Params))
+
+	if err := ch.checkScheduledToolEnabled("conversations_list_scheduled_messages"); err != nil {
+		return nil, err
+	}
+
+	limit := request.GetInt("limit", 100)
+	if limit < 1 || limit > 100 {
+		ch.logger.Warn("Limit out of range, clamping to 1..100", zap.Int("requested", limit))
+		limit = min(max(limit, 1), 100)
+	}
+
+	params := &slack.GetScheduledMessagesParameters{
+		Limit:  limit,
+		Cursor: request.GetString("cursor", ""),
+	}
+	if raw := request.GetString("channel_id", ""); raw != "" {
+		channel, err := resolveChannelID(ctx, ch.apiProvider, ch.logger, raw)
+		if err != nil {
+			return nil, err
+		}
+		params.Channel = channel
+	}
+
+	scheduled, nextCursor, err := listAllowedScheduled(ctx, params, ch.apiProvider.Slack().GetScheduledMessagesContext)
+	if err != nil {
+		ch.logger.Error("Slack GetScheduledMessagesContext failed", zap.Error(err))
+		return nil, err
+	}
+	ch.logger.Debug("Fetched scheduled messages", zap.Int("count", len(scheduled)))
+
+	if len(scheduled) > 0 && nextCursor != "" {
+		scheduled[len(scheduled)-1].Cursor = nextCursor
+	}
+	return pageResult(request, scheduled, nextCursor)
+}
+
+// listAllowedScheduled fetches pages of scheduled messages until the
+// channel policy leaves at least one of them or the cursor runs out, so that
+// a page of messages in disallowed channels does not end the pagination.
+func listAllowedScheduled(ctx context.Context, params *slack.GetScheduledMessagesParameters, fetch func(context.Context, *slack.GetScheduledMessagesParameters) ([]slack.ScheduledMessage, string, error)) ([]ScheduledMessage, string, error) {
+	for {
+		slackScheduled, nextCursor, err := fetch(ctx, params)
+		if err != nil {
+			return nil, "", err
+		}
+
+		var scheduled []ScheduledMessage
+		for _, msg := range slackScheduled {
+			if !isChannelAllowed(msg.Channel) {
+				continue
+			}
+			scheduled = append(scheduled, ScheduledMessage{
+				ScheduledMessageID: msg.ID,
+				Channel:            msg.Channel,
+				PostAt:             time.Unix(int64(msg.PostAt), 0).UTC().Format(time.RFC3339),
+				DateCreated:        time.Unix(int64(msg.DateCreated), 0).UTC().Format(time.RFC3339),
+				Text:               msg.Text,
+			})
+		}
+		if len(scheduled) > 0 || nextCursor == "" {
+			return scheduled, nextCursor, nil
+		}
+
+		next := *params
+		next.Cursor = nextCursor
+		params = &next
+	}
+}
+
+// ConversationsDeleteScheduledMessageHandler cancels a pending scheduled message
+func (ch *ConversationsHandler) ConversationsDeleteScheduledMessageHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ch.logger.Debug("ConversationsDeleteScheduledMessageHandler called", zap.Any("params", request.Params))
+
+	const tool = "conversations_delete_scheduled_message"
+	if err := ch.checkScheduledToolEnabled(tool); err != nil {
+		return nil, err
+	}
+
+	channel, err := resolveChannelID(ctx, ch.apiProvider, ch.logger, request.GetString("channel_id", ""))
+	if err != nil {
+		return nil, err
+	}
+	if !isChannelAllowed(channel) {
+		toolConfig := os.Getenv("SLACK_MCP_ADD_MESSAGE_TOOL")
+		ch.logger.Warn("Scheduled message tool not allowed for channel", zap.String("tool", tool), zap.String("channel", channel), zap.String("policy", toolConfig))
+		return nil, fmt.Errorf("%s tool is not allowed for channel %q, applied policy: %s", tool, channel, toolConfig)
+	}
+
+	scheduledID := strings.TrimSpace(request.GetString("scheduled_message_id", ""))
+	if scheduledID == "" {
+		ch.logger.Error("scheduled_message_id missing in params")
+		return nil, errors.New("scheduled_message_id must be a string")
+	}
+
+	_, err = ch.apiProvider.Slack().DeleteScheduledMessageContext(ctx, &slack.DeleteScheduledMessageParameters{
+		Channel:            channel,
+		ScheduledMessageID: scheduledID,
+	})
+	if err != nil {
+		ch.logger.Error("Slack DeleteScheduledMessageContext failed", zap.Error(err))
+		return nil, err
+	}
+
+	return pageResult(request, []ScheduledMessage{{
+		ScheduledMessageID: scheduledID,
+		Channel:            channel,
+	}}, "")
+}
+
+func (ch *ConversationsHandler) checkScheduledToolEnabled(tool string) error {
+	if os.Getenv("SLACK_MCP_ADD_MESSAGE_TOOL") != "" {
+		return nil
+	}
+	ch.logger.Error("Scheduled message tools disabled by default", zap.String("tool", tool))
+	return fmt.Errorf(
+		"by default, the %s tool is disabled together with conversations_add_message. "+
+			"To enable it, set the SLACK_MCP_ADD_MESSAGE_TOOL environment variable to true, 1, or comma separated list of channels", tool,
+	)
+}
+
+// scheduleLocation returns the timezone explicitly requested, otherwise the
+// Slack profile timezone of the authenticated user, falling back to UTC.
+func (ch *ConversationsHandler) scheduleLocation(tz string) (*time.Location, error) {
+	if tz != "" {
+		loc, err := time.LoadLocation(tz)
+		if err != nil {
+			return nil, fmt.Errorf("unknown timezone %q: %v", tz, err)
+		}
+		return loc, nil
+	}
+
+	authResp, err := ch.apiProvider.Slack().AuthTest()
+	if err != nil {
+		ch.logger.Warn("Slack AuthTest failed, scheduling in UTC", zap.Error(err))
+		return time.UTC, nil
+	}
+	user, ok := ch.apiProvider.Users().Get(authResp.UserID)
+	if !ok || user.TZ == "" {
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(user.TZ)
+	if err != nil {
+		ch.logger.Warn("Unknown user timezone, scheduling in UTC", zap.String("tz", user.TZ), zap.Error(err))
+		return time.UTC, nil
+	}
+	return loc, nil
+}
+
+// parseScheduleTime understands absolute timestamps (RFC3339, unix seconds,
+// "YYYY-MM-DD HH:MM"), relative offsets like "in 2 hours" and a time of day
+// combined with any date parseFlexibleDate accepts, e.g. "9am tomorrow" or
+// "2025-10-01 at 14:30". A bare time of day means its next occurrence.
+func parseScheduleTime(expr string, now time.Time, loc *time.Location) (time.Time, error) {
+	expr = strings.TrimSpace(expr)
+	if expr == "" {
+		return time.Time{}, errors.New("post_at must be a string, e.g. '9am tomorrow', 'in 2 hours' or '2025-10-01T09:00:00Z'")
+	}
+
+	if t, err := time.Parse(time.RFC3339, expr); err == nil {
+		return t, nil
+	}
+	for _, layout := range []string{"2006-01-02T15:04", "2006-01-02 15:04"} {
+		if t, err := time.ParseInLocation(layout, expr, loc); err == nil {
+			return t, nil
+		}
+	}
+	if len(expr) >= 9 {
+		if unix, err := strconv.ParseInt(expr, 10, 64); err == nil {
+			return time.Unix(unix, 0), nil
+		}
+	}
+
+	lower := strings.ToLower(expr)
+	if m := scheduleInRe.FindStringSubmatch(lower); m != nil {
+		n, _ := strconv.Atoi(m[1])
+		switch m[2][0] {
+		case 'm':
+			return now.Add(time.Duration(n) * time.Minute), nil
+		case 'h':
+			return now.Add(time.Duration(n) * time.Hour), nil
+		default:
+			return now.AddDate(0, 0, n), nil
+		}
+	}
+
+	idx := scheduleTimeRe.FindStringSubmatchIndex(lower)
+	if idx == nil {
+		return time.Time{}, fmt.Errorf("unable to parse post_at %q: it must include a time of day such as '9am' or '14:30', or be a timestamp", expr)
+	}
+	group := func(i int) string {
+		if idx[2*i] < 0 {
+			return ""
+		}
+		return lower[idx[2*i]:idx[2*i+1]]
+	}
+
+	var hour, minute int
+	if group(1) != "" {
+		hour, _ = strconv.Atoi(group(1))
+		if group(2) != "" {
+			minute, _ = strconv.Atoi(group(2))
+		}
+		if hour < 1 || hour > 12 {
+			return time.Time{}, fmt.Errorf("invalid hour in post_at %q", expr)
+		}
+		hour %= 12
+		if group(3) == "pm" {
+			hour += 12
+		}
+	} else {
+		hour, _ = strconv.Atoi(group(4))
+		minute, _ = strconv.Atoi(group(5))
+		if hour > 23 {
+			return time.Time{}, fmt.Errorf("invalid hour in post_at %q", expr)
+		}
+	}
+	if minute > 59 {
+		return time.Time{}, fmt.Errorf("invalid minute in post_at %q", expr)
+	}
+
+	localNow := now.In(loc)
+	date := localNow
+	rest := strings.Trim(strings.TrimSpace(lower[:idx[0]]+" "+lower[idx[1]:]), ",")
+	rest = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(rest), "on "))
+	switch rest {
+	case "", "today":
+	case "tomorrow":
+		date = localNow.AddDate(0, 0, 1)
+	default:
+		d, _, err := parseFlexibleDate(rest)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("unable to parse date in post_at %q: %v", expr, err)
+		}
+		date = d
+	}
+
+	t := time.Date(date.Year(), date.Month(), date.Day(), hour, minute, 0, 0, loc)
+	if rest == "" && !t.After(now) {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t, nil
+}
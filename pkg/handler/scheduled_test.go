@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitParseScheduleTime(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	// 2025-03-10 10:30 in Berlin
+	now := time.Date(2025, 3, 10, 9, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		expr     string
+		expected time.Time
+	}{
+		{"9am tomorrow", time.Date(2025, 3, 11, 9, 0, 0, 0, berlin)},
+		{"tomorrow at 9:15am", time.Date(2025, 3, 11, 9, 15, 0, 0, berlin)},
+		{"12pm", time.Date(2025, 3, 10, 12, 0, 0, 0, berlin)},
+		{"9am", time.Date(2025, 3, 11, 9, 0, 0, 0, berlin)},
+		{"today 18:45", time.Date(2025, 3, 10, 18, 45, 0, 0, berlin)},
+		{"2025-04-01 at 14:30", time.Date(2025, 4, 1, 14, 30, 0, 0, berlin)},
+		{"April 2, 2025 8pm", time.Date(2025, 4, 2, 20, 0, 0, 0, berlin)},
+		{"2025-04-01 14:30", time.Date(2025, 4, 1, 14, 30, 0, 0, berlin)},
+		{"2025-04-01T14:30:00Z", time.Date(2025, 4, 1, 14, 30, 0, 0, time.UTC)},
+		{"1743517800", time.Unix(1743517800, 0)},
+		{"in 2 hours", now.Add(2 * time.Hour)},
+		{"in 15 minutes", now.Add(15 * time.Minute)},
+		{"in 3 days", now.AddDate(0, 0, 3)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			got, err := parseScheduleTime(tt.expr, now, berlin)
+			require.NoError(t, err)
+			assert.True(t, tt.expected.Equal(got), "expected %s, got %s", tt.expected, got)
+		})
+	}
+
+	for _, expr := range []string{"", "tomorrow", "13pm", "25:00", "9am someday"} {
+		t.Run("invalid/"+expr, func(t *testing.T) {
+			_, err := parseScheduleTime(expr, now, berlin)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestUnitListAllowedScheduled(t *testing.T) {
+	t.Setenv("SLACK_MCP_ADD_MESSAGE_TOOL", "C1")
+
+	pages := map[string]struct {
+		msgs []slack.ScheduledMessage
+		next string
+	}{
+		"":   {msgs: []slack.ScheduledMessage{{ID: "Q1", Channel: "C2"}}, next: "p2"},
+		"p2": {msgs: []slack.ScheduledMessage{{ID: "Q2", Channel: "C3"}}, next: "p3"},
+		"p3": {msgs: []slack.ScheduledMessage{{ID: "Q3", Channel: "C1"}, {ID: "Q4", Channel: "C2"}}, next: "p4"},
+		"p4": {msgs: []slack.ScheduledMessage{{ID: "Q5", Channel: "C2"}}},
+	}
+	var cursors []string
+	fetch := func(_ context.Context, params *slack.GetScheduledMessagesParameters) ([]slack.ScheduledMessage, string, error) {
+		cursors = append(cursors, params.Cursor)
+		return pages[params.Cursor].msgs, pages[params.Cursor].next, nil
+	}
+
+	// Pages of disallowed channels are skipped rather than ending the list.
+	scheduled, next, err := listAllowedScheduled(context.Background(), &slack.GetScheduledMessagesParameters{Limit: 1}, fetch)
+	require.NoError(t, err)
+	require.Len(t, scheduled, 1)
+	assert.Equal(t, "Q3", scheduled[0].ScheduledMessageID)
+	assert.Equal(t, "p4", next)
+	assert.Equal(t, []string{"", "p2", "p3"}, cursors)
+
+	// The last page may still come out empty.
+	scheduled, next, err = listAllowedScheduled(context.Background(), &slack.GetScheduledMessagesParameters{Cursor: "p4"}, fetch)
+	require.NoError(t, err)
+	assert.Empty(t, scheduled)
+	assert.Empty(t, next)
+}
@@ -0,0 +1,244 @@
+package handler
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gocarina/gocsv"
+	"github.com/korotovsky/slack-mcp-server/pkg/limiter"
+	"github.com/korotovsky/slack-mcp-server/pkg/progress"
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/korotovsky/slack-mcp-server/pkg/provider/edge"
+	"github.com/korotovsky/slack-mcp-server/pkg/provider/edge/fasttime"
+	"github.com/korotovsky/slack-mcp-server/pkg/text"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/slack-go/slack"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultUnreadsLimit         = 50
+	defaultUnreadsMessagesLimit = 10
+)
+
+type Unread struct {
+	ChannelID    string `json:"channelID"`
+	ChannelName  string `json:"channelName"`
+	ChannelType  string `json:"channelType"`
+	LastRead     string `json:"lastRead"`
+	Latest       string `json:"latest"`
+	MentionCount int    `json:"mentionCount"`
+}
+
+// UnreadsPage is the structured content of unreads_list, with the unread
+// messages next to the conversations when include_messages is set.
+type UnreadsPage struct {
+	Items    []Unread  `json:"items"`
+	Messages []Message `json:"messages,omitempty"`
+}
+
+type unreadsParams struct {
+	types           map[string]bool
+	mentionsOnly    bool
+	includeMessages bool
+	messagesLimit   int
+	limit           int
+}
+
+type unreadSnapshot struct {
+	edge.ChannelSnapshot
+	chanType string
+}
+
+// UnreadsListHandler lists conversations with unread messages as CSV,
+// optionally followed by a second CSV with the unread messages themselves.
+func (ch *ConversationsHandler) UnreadsListHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ch.logger.Debug("UnreadsListHandler called", zap.Any("params", request.Params))
+
+	if ready, err := ch.apiProvider.IsReady(); !ready {
+		ch.logger.Error("API provider not ready", zap.Error(err))
+		return nil, err
+	}
+
+	params := ch.parseParamsToolUnreads(ctx, request)
+
+	counts, err := ch.apiProvider.Slack().ClientCounts(ctx)
+	if err != nil {
+		ch.logger.Error("Slack ClientCounts failed", zap.Error(err))
+		return nil, err
+	}
+
+	channels := ch.apiProvider.Channels()
+	snapshots := collectUnreads(counts, channels, params.types, params.mentionsOnly)
+	ch.logger.Debug("Collected unread conversations", zap.Int("count", len(snapshots)))
+
+	if len(snapshots) > params.limit {
+		snapshots = snapshots[:params.limit]
+	}
+
+	unreads := make([]Unread, 0, len(snapshots))
+	for _, s := range snapshots {
+		name := s.ID
+		if c, ok := channels.Get(s.ID); ok {
+			name = c.Name
+		}
+		unreads = append(unreads, Unread{
+			ChannelID:    s.ID,
+			ChannelName:  name,
+			ChannelType:  s.chanType,
+			LastRead:     snapshotTime(s.LastRead),
+			Latest:       snapshotTime(s.Latest),
+			MentionCount: s.MentionCount,
+		})
+	}
+
+	if !params.includeMessages {
+		result, err := toolResult(request, &unreads, UnreadsPage{Items: unreads})
+		if err != nil {
+			ch.logger.Error("Failed to marshal unreads", zap.Error(err))
+			return nil, err
+		}
+		return result, nil
+	}
+
+	var (
+		messages []Message
+		lim      = limiter.Tier3.Limiter()
+	)
+	for i, s := range snapshots {
+		progress.Report(ctx, float64(i), float64(len(snapshots)), "Fetching unread messages of "+s.ID)
+		if err := lim.Wait(ctx); err != nil {
+			ch.logger.Error("Rate limiter wait failed", zap.Error(err))
+			return nil, err
+		}
+
+		historyParams := slack.GetConversationHistoryParameters{
+			ChannelID: s.ID,
+			Limit:     params.messagesLimit,
+			Inclusive: false,
+		}
+		if !time.Time(s.LastRead).IsZero() {
+			historyParams.Oldest = s.LastRead.SlackString()
+		}
+
+		history, err := ch.apiProvider.Slack().GetConversationHistoryContext(ctx, &historyParams)
+		if err != nil {
+			ch.logger.Warn("GetConversationHistoryContext failed for unread conversation",
+				zap.String("channel", s.ID),
+				zap.Error(err),
+			)
+			continue
+		}
+
+		messages = append(messages, ch.convertMessagesFromHistory(ctx, history.Messages, s.ID, false)...)
+	}
+	ch.logger.Debug("Fetched unread messages", zap.Int("count", len(messages)))
+
+	result, err := toolResult(request, &unreads, UnreadsPage{Items: unreads, Messages: messages})
+	if err != nil {
+		ch.logger.Error("Failed to marshal unreads", zap.Error(err))
+		return nil, err
+	}
+	if isJSONOutput(request) {
+		return result, nil
+	}
+
+	messagesCSV, err := gocsv.MarshalBytes(&messages)
+	if err != nil {
+		ch.logger.Error("Failed to marshal unread messages to CSV", zap.Error(err))
+		return nil, err
+	}
+	result.Content = append(result.Content, mcp.NewTextContent(string(messagesCSV)))
+	return result, nil
+}
+
+func (ch *ConversationsHandler) parseParamsToolUnreads(ctx context.Context, request mcp.CallToolRequest) *unreadsParams {
+	types := make(map[string]bool, len(provider.AllChanTypes))
+	for _, t := range strings.Split(request.GetString("channel_types", ""), ",") {
+		t = strings.TrimSpace(t)
+		if t == "" {
+			continue
+		}
+		types[t] = true
+	}
+	if len(types) == 0 {
+		for _, t := range provider.AllChanTypes {
+			types[t] = true
+		}
+	}
+
+	limit := request.GetInt("limit", defaultUnreadsLimit)
+	if limit <= 0 {
+		limit = defaultUnreadsLimit
+	}
+
+	messagesLimit := request.GetInt("messages_limit", defaultUnreadsMessagesLimit)
+	if messagesLimit <= 0 {
+		messagesLimit = defaultUnreadsMessagesLimit
+	}
+	if messagesLimit > 100 {
+		ch.logger.Warn("Messages limit exceeds maximum, capping to 100", zap.Int("requested", messagesLimit))
+		messagesLimit = 100
+	}
+
+	return &unreadsParams{
+		types:           types,
+		mentionsOnly:    request.GetBool("mentions_only", false),
+		includeMessages: request.GetBool("include_messages", false),
+		messagesLimit:   messagesLimit,
+		limit:           limit,
+	}
+}
+
+// collectUnreads flattens client.counts into a single list of conversations
+// with unread messages, ordered by mentions first and recency second.
+func collectUnreads(counts edge.ClientCountsResponse, channels *provider.ChannelsSnapshot, types map[string]bool, mentionsOnly bool) []unreadSnapshot {
+	var res []unreadSnapshot
+
+	add := func(snapshots []edge.ChannelSnapshot, typeFn func(edge.ChannelSnapshot) string) {
+		for _, s := range snapshots {
+			if !s.HasUnreads && s.MentionCount == 0 {
+				continue
+			}
+			if mentionsOnly && s.MentionCount == 0 {
+				continue
+			}
+			t := typeFn(s)
+			if !types[t] {
+				continue
+			}
+			res = append(res, unreadSnapshot{ChannelSnapshot: s, chanType: t})
+		}
+	}
+
+	add(counts.Channels, func(s edge.ChannelSnapshot) string {
+		if c, ok := channels.Get(s.ID); ok && c.IsPrivate {
+			return provider.PrivateChanType
+		}
+		return provider.PubChanType
+	})
+	add(counts.MPIMs, func(edge.ChannelSnapshot) string { return "mpim" })
+	add(counts.IMs, func(edge.ChannelSnapshot) string { return "im" })
+
+	sort.SliceStable(res, func(i, j int) bool {
+		if res[i].MentionCount != res[j].MentionCount {
+			return res[i].MentionCount > res[j].MentionCount
+		}
+		return time.Time(res[i].Latest).After(time.Time(res[j].Latest))
+	})
+
+	return res
+}
+
+func snapshotTime(t fasttime.Time) string {
+	if time.Time(t).IsZero() {
+		return ""
+	}
+	ts, err := text.TimestampToIsoRFC3339(t.SlackString())
+	if err != nil {
+		return ""
+	}
+	return ts
+}
@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/korotovsky/slack-mcp-server/pkg/provider/edge"
+	"github.com/korotovsky/slack-mcp-server/pkg/provider/edge/fasttime"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnitCollectUnreads(t *testing.T) {
+	now := time.Now()
+	ts := func(d time.Duration) fasttime.Time { return fasttime.Time(now.Add(-d)) }
+
+	counts := edge.ClientCountsResponse{
+		Channels: []edge.ChannelSnapshot{
+			{ID: "C1", HasUnreads: true, Latest: ts(3 * time.Hour)},
+			{ID: "C2", HasUnreads: true, MentionCount: 2, Latest: ts(5 * time.Hour)},
+			{ID: "C3", HasUnreads: false, Latest: ts(time.Hour)},
+			{ID: "G1", HasUnreads: true, Latest: ts(time.Minute)},
+		},
+		MPIMs: []edge.ChannelSnapshot{
+			{ID: "G2", HasUnreads: true, Latest: ts(2 * time.Hour)},
+		},
+		IMs: []edge.ChannelSnapshot{
+			{ID: "D1", HasUnreads: true, MentionCount: 1, Latest: ts(4 * time.Hour)},
+		},
+	}
+	channels := provider.NewChannelsSnapshot([]provider.Channel{
+		{ID: "C1", Name: "#general"},
+		{ID: "G1", Name: "#secret", IsPrivate: true},
+	})
+	allTypes := map[string]bool{"public_channel": true, "private_channel": true, "im": true, "mpim": true}
+
+	ids := func(res []unreadSnapshot) []string {
+		var out []string
+		for _, r := range res {
+			out = append(out, r.ID+"/"+r.chanType)
+		}
+		return out
+	}
+
+	t.Run("all types sorted by mentions then recency", func(t *testing.T) {
+		res := collectUnreads(counts, channels, allTypes, false)
+		assert.Equal(t, []string{
+			"C2/public_channel",
+			"D1/im",
+			"G1/private_channel",
+			"G2/mpim",
+			"C1/public_channel",
+		}, ids(res))
+	})
+
+	t.Run("mentions only", func(t *testing.T) {
+		res := collectUnreads(counts, channels, allTypes, true)
+		assert.Equal(t, []string{"C2/public_channel", "D1/im"}, ids(res))
+	})
+
+	t.Run("filtered by type", func(t *testing.T) {
+		res := collectUnreads(counts, channels, map[string]bool{"im": true, "private_channel": true}, false)
+		assert.Equal(t, []string{"D1/im", "G1/private_channel"}, ids(res))
+	})
+}
@@ -0,0 +1,296 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/slack-go/slack"
+	"go.uber.org/zap"
+)
+
+const defaultUsersSearchLimit = 10
+
+type UserDetails struct {
+	UserID       string `json:"userID"`
+	UserName     string `json:"userName"`
+	RealName     string `json:"realName"`
+	DisplayName  string `json:"displayName"`
+	Email        string `json:"email"`
+	Title        string `json:"title"`
+	Tz           string `json:"tz"`
+	StatusText   string `json:"statusText"`
+	StatusEmoji  string `json:"statusEmoji"`
+	IsBot        bool   `json:"isBot"`
+	IsAdmin      bool   `json:"isAdmin"`
+	IsRestricted bool   `json:"isRestricted"`
+	Deleted      bool   `json:"deleted"`
+}
+
+type UsersHandler struct {
+	apiProvider *provider.ApiProvider
+	logger      *zap.Logger
+}
+
+func NewUsersHandler(apiProvider *provider.ApiProvider, logger *zap.Logger) *UsersHandler {
+	return &UsersHandler{
+		apiProvider: apiProvider,
+		logger:      logger,
+	}
+}
+
+// UsersSearchHandler finds users in the users cache by fuzzy matching the query
+// against their ID, handle, real and display names, email and title.
+func (uh *UsersHandler) UsersSearchHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	uh.logger.Debug("UsersSearchHandler called", zap.Any("params", request.Params))
+
+	if ready, err := uh.apiProvider.IsReady(); !ready && errors.Is(err, provider.ErrUsersNotReady) {
+		uh.logger.Error("API provider not ready", zap.Error(err))
+		return nil, err
+	}
+
+	query := strings.TrimSpace(request.GetString("query", ""))
+	if query == "" {
+		uh.logger.Error("query missing in users_search params")
+		return nil, errors.New("query must be a non-empty string")
+	}
+
+	limit := request.GetInt("limit", defaultUsersSearchLimit)
+	if limit <= 0 {
+		limit = defaultUsersSearchLimit
+	}
+	if limit > 100 {
+		uh.logger.Warn("Limit exceeds maximum, capping to 100", zap.Int("requested", limit))
+		limit = 100
+	}
+	includeDeleted := request.GetBool("include_deleted", false)
+	includeBots := request.GetBool("include_bots", false)
+
+	users := uh.apiProvider.Users().All()
+	matches := searchUsers(users, query, includeDeleted, includeBots)
+	uh.logger.Debug("Users search completed",
+		zap.String("query", query),
+		zap.Int("matches", len(matches)),
+	)
+
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+
+	return marshalUsers(request, matches)
+}
+
+// UsersInfoHandler returns user profiles for the given comma-separated list of
+// user IDs or @handles.
+func (uh *UsersHandler) UsersInfoHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	uh.logger.Debug("UsersInfoHandler called", zap.Any("params", request.Params))
+
+	if ready, err := uh.apiProvider.IsReady(); !ready && errors.Is(err, provider.ErrUsersNotReady) {
+		uh.logger.Error("API provider not ready", zap.Error(err))
+		return nil, err
+	}
+
+	found, err := uh.resolveUsersParam(ctx, request.GetString("users", ""))
+	if err != nil {
+		return nil, err
+	}
+
+	return marshalUsers(request, found)
+}
+
+// resolveUsersParam resolves a comma-separated list of user IDs or @handles
+// against the users cache, failing only when none of them is known.
+func (uh *UsersHandler) resolveUsersParam(ctx context.Context, raw string) ([]slack.User, error) {
+	if strings.TrimSpace(raw) == "" {
+		uh.logger.Error("users missing in params")
+		return nil, errors.New("users must be a comma-separated list of user IDs or @handles")
+	}
+
+	var (
+		found    []slack.User
+		notFound []string
+	)
+	for _, item := range strings.Split(raw, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		u, ok := lookupWithRefresh(ctx, uh.apiProvider, func() (slack.User, bool) {
+			return lookupUser(uh.apiProvider.Users(), item)
+		})
+		if !ok {
+			notFound = append(notFound, item)
+			continue
+		}
+		found = append(found, u)
+	}
+
+	if len(notFound) > 0 {
+		uh.logger.Warn("Some users were not found", zap.Strings("users", notFound))
+	}
+	if len(found) == 0 {
+		return nil, fmt.Errorf("users %q not found", strings.Join(notFound, ", "))
+	}
+	return found, nil
+}
+
+// lookupUser resolves a user by ID, <@ID>, @handle or bare handle.
+func lookupUser(users *provider.UsersSnapshot, raw string) (slack.User, bool) {
+	raw = strings.TrimSuffix(strings.TrimPrefix(raw, "<@"), ">")
+	if u, ok := users.Get(raw); ok {
+		return u, true
+	}
+	return users.ByName(strings.TrimPrefix(raw, "@"))
+}
+
+// searchUsers scores every user against the query and returns the matching
+// ones sorted by descending score, ties broken by handle.
+func searchUsers(users []slack.User, query string, includeDeleted, includeBots bool) []slack.User {
+	type scored struct {
+		user  slack.User
+		score int
+	}
+
+	var res []scored
+	for _, u := range users {
+		if u.Deleted && !includeDeleted {
+			continue
+		}
+		if (u.IsBot || u.IsAppUser) && !includeBots {
+			continue
+		}
+		if s := scoreUser(u, query); s > 0 {
+			res = append(res, scored{user: u, score: s})
+		}
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].score != res[j].score {
+			return res[i].score > res[j].score
+		}
+		return res[i].user.Name < res[j].user.Name
+	})
+
+	out := make([]slack.User, 0, len(res))
+	for _, r := range res {
+		out = append(out, r.user)
+	}
+	return out
+}
+
+// scoreUser returns how well the user matches the query, 0 means no match.
+// Exact identifiers win over prefixes, prefixes over substrings, and every
+// query word is matched on its own so "jane payments" finds Jane whose title
+// mentions Payments. Words of 4+ characters tolerate a single typo.
+func scoreUser(u slack.User, query string) int {
+	q := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(query), "@"))
+	if q == "" {
+		return 0
+	}
+
+	ids := []string{
+		strings.ToLower(u.ID),
+		strings.ToLower(u.Name),
+		strings.ToLower(u.Profile.Email),
+	}
+	for _, id := range ids {
+		if id != "" && id == q {
+			return 1000
+		}
+	}
+
+	fields := []string{
+		strings.ToLower(u.Name),
+		strings.ToLower(u.RealName),
+		strings.ToLower(u.Profile.RealName),
+		strings.ToLower(u.Profile.DisplayName),
+		strings.ToLower(u.Profile.Email),
+		strings.ToLower(u.Profile.Title),
+	}
+
+	score := 0
+	for _, f := range fields[:4] {
+		if f == "" {
+			continue
+		}
+		if f == q {
+			score += 500
+		} else if strings.HasPrefix(f, q) {
+			score += 200
+		}
+	}
+
+	var words []string
+	for _, f := range fields {
+		words = append(words, strings.FieldsFunc(f, func(r rune) bool {
+			return r == ' ' || r == '.' || r == '-' || r == '_' || r == '@' || r == ','
+		})...)
+	}
+
+	for _, token := range strings.Fields(q) {
+		best := 0
+		for _, w := range words {
+			switch {
+			case w == token:
+				best = max(best, 100)
+			case strings.HasPrefix(w, token):
+				best = max(best, 60)
+			case strings.Contains(w, token):
+				best = max(best, 30)
+			case len(token) >= 4 && levenshtein(w, token) <= 1:
+				best = max(best, 20)
+			}
+		}
+		score += best
+	}
+
+	return score
+}
+
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
+
+func marshalUsers(request mcp.CallToolRequest, users []slack.User) (*mcp.CallToolResult, error) {
+	details := make([]UserDetails, 0, len(users))
+	for _, u := range users {
+		details = append(details, UserDetails{
+			UserID:       u.ID,
+			UserName:     u.Name,
+			RealName:     u.RealName,
+			DisplayName:  u.Profile.DisplayName,
+			Email:        u.Profile.Email,
+			Title:        u.Profile.Title,
+			Tz:           u.TZ,
+			StatusText:   u.Profile.StatusText,
+			StatusEmoji:  u.Profile.StatusEmoji,
+			IsBot:        u.IsBot,
+			IsAdmin:      u.IsAdmin,
+			IsRestricted: u.IsRestricted,
+			Deleted:      u.Deleted,
+		})
+	}
+
+	return pageResult(request, details, "")
+}
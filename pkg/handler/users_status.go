@@ -0,0 +1,188 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/limiter"
+	"github.com/korotovsky/slack-mcp-server/pkg/progress"
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/slack-go/slack"
+	"go.uber.org/zap"
+)
+
+// users.getPresence has to be called once per user.
+const maxUsersStatus = 20
+
+type UserStatus struct {
+	UserID           string `json:"userID"`
+	UserName         string `json:"userName"`
+	RealName         string `json:"realName"`
+	Presence         string `json:"presence"`
+	LastActivity     string `json:"lastActivity"`
+	StatusText       string `json:"statusText"`
+	StatusEmoji      string `json:"statusEmoji"`
+	StatusExpiration string `json:"statusExpiration"`
+	DNDEnabled       bool   `json:"dndEnabled"`
+	InDND            bool   `json:"inDnd"`
+	NextDNDStart     string `json:"nextDndStart"`
+	NextDNDEnd       string `json:"nextDndEnd"`
+	SnoozeEnd        string `json:"snoozeEnd"`
+	Tz               string `json:"tz"`
+	LocalTime        string `json:"localTime"`
+}
+
+// UsersStatusHandler reports presence, custom status and do-not-disturb
+// windows for the given users.
+func (uh *UsersHandler) UsersStatusHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	uh.logger.Debug("UsersStatusHandler called", zap.Any("params", request.Params))
+
+	if ready, err := uh.apiProvider.IsReady(); !ready && errors.Is(err, provider.ErrUsersNotReady) {
+		uh.logger.Error("API provider not ready", zap.Error(err))
+		return nil, err
+	}
+
+	users, err := uh.resolveUsersParam(ctx, request.GetString("users", ""))
+	if err != nil {
+		return nil, err
+	}
+	if len(users) > maxUsersStatus {
+		return nil, fmt.Errorf("users_status accepts at most %d users per call, got %d", maxUsersStatus, len(users))
+	}
+
+	ids := make([]string, 0, len(users))
+	for _, u := range users {
+		ids = append(ids, u.ID)
+	}
+
+	// The cache can be hours old, custom statuses change more often.
+	if fresh, err := uh.apiProvider.Slack().GetUsersInfo(ids...); err != nil {
+		uh.logger.Warn("Slack GetUsersInfo failed, using cached profiles", zap.Error(err))
+	} else if fresh != nil && len(*fresh) == len(users) {
+		users = *fresh
+	}
+
+	dnd, err := uh.apiProvider.Slack().GetDNDTeamInfoContext(ctx, ids)
+	if err != nil {
+		uh.logger.Warn("Slack GetDNDTeamInfoContext failed, falling back to userBoot", zap.Error(err))
+		dnd = uh.selfDNDFromBoot(ctx, ids)
+	}
+
+	lim := limiter.Tier3.Limiter()
+	now := time.Now()
+
+	statuses := make([]UserStatus, 0, len(users))
+	for i, u := range users {
+		progress.Report(ctx, float64(i), float64(len(users)), "Fetching presence of "+u.ID)
+		var presence *slack.UserPresence
+		if err := lim.Wait(ctx); err != nil {
+			uh.logger.Error("Rate limiter wait failed", zap.Error(err))
+			return nil, err
+		}
+		presence, err = uh.apiProvider.Slack().GetUserPresenceContext(ctx, u.ID)
+		if err != nil {
+			uh.logger.Warn("Slack GetUserPresenceContext failed", zap.String("user", u.ID), zap.Error(err))
+		}
+
+		var userDND *slack.DNDStatus
+		if s, ok := dnd[u.ID]; ok {
+			userDND = &s
+		}
+
+		statuses = append(statuses, buildUserStatus(u, presence, userDND, now))
+	}
+
+	result, err := pageResult(request, statuses, "")
+	if err != nil {
+		uh.logger.Error("Failed to marshal user statuses", zap.Error(err))
+		return nil, err
+	}
+	return result, nil
+}
+
+// selfDNDFromBoot falls back to the DND section of client.userBoot when
+// dnd.teamInfo is unavailable, which only covers the authenticated user.
+func (uh *UsersHandler) selfDNDFromBoot(ctx context.Context, ids []string) map[string]slack.DNDStatus {
+	authResp, err := uh.apiProvider.Slack().AuthTest()
+	if err != nil {
+		return nil
+	}
+	requested := false
+	for _, id := range ids {
+		if id == authResp.UserID {
+			requested = true
+			break
+		}
+	}
+	if !requested {
+		return nil
+	}
+
+	boot, err := uh.apiProvider.Slack().ClientUserBoot(ctx)
+	if err != nil {
+		uh.logger.Warn("Slack ClientUserBoot failed", zap.Error(err))
+		return nil
+	}
+	return map[string]slack.DNDStatus{
+		authResp.UserID: {
+			Enabled:            boot.DND.DNDEnabled,
+			NextStartTimestamp: int(boot.DND.NextDNDStartTs),
+			NextEndTimestamp:   int(boot.DND.NextDNDEndTs),
+			SnoozeInfo:         slack.SnoozeInfo{SnoozeEnabled: boot.DND.SnoozeEnabled},
+		},
+	}
+}
+
+func buildUserStatus(u slack.User, presence *slack.UserPresence, dnd *slack.DNDStatus, now time.Time) UserStatus {
+	status := UserStatus{
+		UserID:      u.ID,
+		UserName:    u.Name,
+		RealName:    u.RealName,
+		StatusText:  u.Profile.StatusText,
+		StatusEmoji: u.Profile.StatusEmoji,
+		Tz:          u.TZ,
+	}
+
+	if u.Profile.StatusExpiration > 0 {
+		status.StatusExpiration = formatUnix(int64(u.Profile.StatusExpiration))
+	}
+
+	if presence != nil {
+		status.Presence = presence.Presence
+		if presence.LastActivity != 0 {
+			status.LastActivity = presence.LastActivity.Time().UTC().Format(time.RFC3339)
+		}
+	}
+
+	if dnd != nil {
+		status.DNDEnabled = dnd.Enabled
+		if dnd.Enabled && dnd.NextStartTimestamp > 0 {
+			status.NextDNDStart = formatUnix(int64(dnd.NextStartTimestamp))
+			status.NextDNDEnd = formatUnix(int64(dnd.NextEndTimestamp))
+			start := time.Unix(int64(dnd.NextStartTimestamp), 0)
+			end := time.Unix(int64(dnd.NextEndTimestamp), 0)
+			status.InDND = !now.Before(start) && now.Before(end)
+		}
+		if dnd.SnoozeEnabled && dnd.SnoozeEndTime > 0 {
+			status.SnoozeEnd = formatUnix(int64(dnd.SnoozeEndTime))
+			if now.Before(time.Unix(int64(dnd.SnoozeEndTime), 0)) {
+				status.InDND = true
+			}
+		}
+	}
+
+	if u.TZ != "" {
+		if loc, err := time.LoadLocation(u.TZ); err == nil {
+			status.LocalTime = now.In(loc).Format(time.RFC3339)
+		}
+	}
+
+	return status
+}
+
+func formatUnix(sec int64) string {
+	return time.Unix(sec, 0).UTC().Format(time.RFC3339)
+}
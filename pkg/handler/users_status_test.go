@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnitBuildUserStatus(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	friday := time.Date(2025, 3, 14, 18, 0, 0, 0, time.UTC)
+
+	u := slack.User{
+		ID:       "U3",
+		Name:     "bob",
+		RealName: "Bob Payne",
+		TZ:       "America/New_York",
+		Profile: slack.UserProfile{
+			StatusText:       "OOO",
+			StatusEmoji:      ":palm_tree:",
+			StatusExpiration: int(friday.Unix()),
+		},
+	}
+	presence := &slack.UserPresence{Presence: "away"}
+	dnd := &slack.DNDStatus{
+		Enabled:            true,
+		NextStartTimestamp: int(now.Add(-time.Hour).Unix()),
+		NextEndTimestamp:   int(now.Add(time.Hour).Unix()),
+	}
+
+	status := buildUserStatus(u, presence, dnd, now)
+	assert.Equal(t, "away", status.Presence)
+	assert.Equal(t, "OOO", status.StatusText)
+	assert.Equal(t, "2025-03-14T18:00:00Z", status.StatusExpiration)
+	assert.True(t, status.DNDEnabled)
+	assert.True(t, status.InDND)
+	assert.Equal(t, "2025-03-10T08:00:00-04:00", status.LocalTime)
+
+	snoozed := &slack.DNDStatus{SnoozeInfo: slack.SnoozeInfo{SnoozeEnabled: true, SnoozeEndTime: int(now.Add(30 * time.Minute).Unix())}}
+	status = buildUserStatus(u, nil, snoozed, now)
+	assert.Empty(t, status.Presence)
+	assert.True(t, status.InDND)
+	assert.Equal(t, "2025-03-10T12:30:00Z", status.SnoozeEnd)
+
+	status = buildUserStatus(slack.User{ID: "U1"}, nil, nil, now)
+	assert.Equal(t, UserStatus{UserID: "U1"}, status)
+}
@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+func testUsers() []slack.User {
+	return []slack.User{
+		{ID: "U1", Name: "jane", RealName: "Jane Doe", Profile: slack.UserProfile{Email: "jane.doe@example.com", Title: "Payments Engineer", DisplayName: "jd"}},
+		{ID: "U2", Name: "janet", RealName: "Janet Smith", Profile: slack.UserProfile{Email: "janet@example.com", Title: "Designer"}},
+		{ID: "U3", Name: "bob", RealName: "Bob Payne", Profile: slack.UserProfile{Title: "Payments Lead"}},
+		{ID: "U4", Name: "old.jane", RealName: "Jane Old", Deleted: true},
+		{ID: "B1", Name: "janebot", RealName: "Jane Bot", IsBot: true},
+	}
+}
+
+func TestUnitSearchUsers(t *testing.T) {
+	names := func(users []slack.User) []string {
+		var out []string
+		for _, u := range users {
+			out = append(out, u.Name)
+		}
+		return out
+	}
+
+	tests := []struct {
+		name           string
+		query          string
+		includeDeleted bool
+		includeBots    bool
+		expected       []string
+	}{
+		{name: "exact handle wins", query: "@jane", expected: []string{"jane", "janet"}},
+		{name: "exact id", query: "U3", expected: []string{"bob"}},
+		{name: "email", query: "janet@example.com", expected: []string{"janet"}},
+		{name: "words across name and title", query: "jane payments", expected: []string{"jane", "bob", "janet"}},
+		{name: "typo tolerance", query: "smyth", expected: []string{"janet"}},
+		{name: "deleted included", query: "jane old", includeDeleted: true, expected: []string{"old.jane", "jane", "janet"}},
+		{name: "bots included", query: "janebot", includeBots: true, expected: []string{"janebot"}},
+		{name: "no match", query: "zzz", expected: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := searchUsers(testUsers(), tt.query, tt.includeDeleted, tt.includeBots)
+			assert.Equal(t, tt.expected, names(res))
+		})
+	}
+}
+
+func TestUnitLookupUser(t *testing.T) {
+	cache := provider.NewUsersSnapshot(testUsers())
+
+	for _, raw := range []string{"U1", "<@U1>", "@jane", "jane"} {
+		u, ok := lookupUser(cache, raw)
+		assert.True(t, ok, raw)
+		assert.Equal(t, "U1", u.ID, raw)
+	}
+
+	_, ok := lookupUser(cache, "@nobody")
+	assert.False(t, ok)
+}
@@ -22,5 +22,5 @@ var (
 	Tier2      = tier{t: 3 * time.Second, b: 3}
 	Tier2boost = tier{t: 300 * time.Millisecond, b: 5}
 	Tier3      = tier{t: 1200 * time.Millisecond, b: 4}
-	// tier4      = tier{t: 60 * time.Millisecond, b: 5}
+	Tier4      = tier{t: 600 * time.Millisecond, b: 5}
 )
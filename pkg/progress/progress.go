@@ -0,0 +1,23 @@
+// Package progress carries a progress callback through the context, so that
+// long operations in the provider and handlers can report how far they got
+// without knowing who is listening.
+package progress
+
+import "context"
+
+// Reporter receives the progress of an operation. total is 0 when unknown.
+type Reporter func(progress, total float64, message string)
+
+type reporterKey struct{}
+
+// WithReporter returns a copy of ctx that delivers Report calls to r.
+func WithReporter(ctx context.Context, r Reporter) context.Context {
+	return context.WithValue(ctx, reporterKey{}, r)
+}
+
+// Report forwards progress to the reporter in ctx, if any.
+func Report(ctx context.Context, progress, total float64, message string) {
+	if r, ok := ctx.Value(reporterKey{}).(Reporter); ok && r != nil {
+		r(progress, total, message)
+	}
+}
@@ -2,14 +2,23 @@ package provider
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"os"
+	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/korotovsky/slack-mcp-server/pkg/limiter"
+	"github.com/korotovsky/slack-mcp-server/pkg/progress"
 	"github.com/korotovsky/slack-mcp-server/pkg/provider/edge"
 	"github.com/korotovsky/slack-mcp-server/pkg/transport"
+	rusqslack "github.com/rusq/slack"
 	"github.com/rusq/slackdump/v3/auth"
 	"github.com/slack-go/slack"
 	"go.uber.org/zap"
@@ -27,16 +36,6 @@ var PubChanType = "public_channel"
 var ErrUsersNotReady = errors.New(usersNotReadyMsg)
 var ErrChannelsNotReady = errors.New(channelsNotReadyMsg)
 
-type UsersCache struct {
-	Users    map[string]slack.User `json:"users"`
-	UsersInv map[string]string     `json:"users_inv"`
-}
-
-type ChannelsCache struct {
-	Channels    map[string]Channel `json:"channels"`
-	ChannelsInv map[string]string  `json:"channels_inv"`
-}
-
 type Channel struct {
 	ID          string `json:"id"`
 	Name        string `json:"name"`
@@ -54,19 +53,44 @@ type SlackAPI interface {
 	AuthTestContext(ctx context.Context) (*slack.AuthTestResponse, error)
 	GetUsersContext(ctx context.Context, options ...slack.GetUsersOption) ([]slack.User, error)
 	GetUsersInfo(users ...string) (*[]slack.User, error)
+	UsersInfo(ctx context.Context, userIDs ...string) ([]slack.User, error)
+	GetUserPresenceContext(ctx context.Context, user string) (*slack.UserPresence, error)
+	GetDNDTeamInfoContext(ctx context.Context, users []string) (map[string]slack.DNDStatus, error)
 	PostMessageContext(ctx context.Context, channel string, options ...slack.MsgOption) (string, string, error)
+	UpdateMessageContext(ctx context.Context, channel, timestamp string, options ...slack.MsgOption) (string, string, string, error)
+	DeleteMessageContext(ctx context.Context, channel, messageTimestamp string) (string, string, error)
+	ScheduleMessageContext(ctx context.Context, channelID, postAt string, options ...slack.MsgOption) (string, string, error)
+	GetScheduledMessagesContext(ctx context.Context, params *slack.GetScheduledMessagesParameters) ([]slack.ScheduledMessage, string, error)
+	DeleteScheduledMessageContext(ctx context.Context, params *slack.DeleteScheduledMessageParameters) (bool, error)
 	MarkConversationContext(ctx context.Context, channel, ts string) error
 
+	// Used to manage reactions on messages
+	AddReactionContext(ctx context.Context, name string, item slack.ItemRef) error
+	RemoveReactionContext(ctx context.Context, name string, item slack.ItemRef) error
+	GetReactionsContext(ctx context.Context, item slack.ItemRef, params slack.GetReactionsParameters) ([]slack.ItemReaction, error)
+
 	// Useed to get messages
 	GetConversationHistoryContext(ctx context.Context, params *slack.GetConversationHistoryParameters) (*slack.GetConversationHistoryResponse, error)
 	GetConversationRepliesContext(ctx context.Context, params *slack.GetConversationRepliesParameters) (msgs []slack.Message, hasMore bool, nextCursor string, err error)
 	SearchContext(ctx context.Context, query string, params slack.SearchParameters) (*slack.SearchMessages, *slack.SearchFiles, error)
 
+	// Used to search and download files
+	SearchFilesContext(ctx context.Context, query string, params slack.SearchParameters) (*slack.SearchFiles, error)
+	GetFileInfoContext(ctx context.Context, fileID string, count, page int) (*slack.File, []slack.Comment, *slack.Paging, error)
+	GetFileContext(ctx context.Context, downloadURL string, writer io.Writer) error
+
 	// Useed to get channels list from both Slack and Enterprise Grid versions
 	GetConversationsContext(ctx context.Context, params *slack.GetConversationsParameters) ([]slack.Channel, string, error)
+	GetConversationInfoContext(ctx context.Context, input *slack.GetConversationInfoInput) (*slack.Channel, error)
+
+	// Used to list and check channel members
+	GetUsersInConversationContext(ctx context.Context, params *slack.GetUsersInConversationParameters) ([]string, string, error)
+	ChannelNonMembers(ctx context.Context, channelID string, userIDs []string) ([]string, error)
 
 	// Edge API methods
 	ClientUserBoot(ctx context.Context) (*edge.ClientUserBootResponse, error)
+	ClientCounts(ctx context.Context) (edge.ClientCountsResponse, error)
+	SubscriptionsThreadMark(ctx context.Context, channelID, threadTS, ts string) error
 }
 
 type MCPSlackClient struct {
@@ -87,19 +111,44 @@ type ApiProvider struct {
 	logger    *zap.Logger
 
 	rateLimiter *rate.Limiter
+	// usersInfoLimiter paces users.info, a higher Slack tier than the
+	// listing calls, so that resolving users does not wait on a refresh.
+	usersInfoLimiter *rate.Limiter
 
-	// Mutex to protect concurrent access to users and channels data
-	mu sync.RWMutex
+	// users and channels are immutable snapshots, read without locking and
+	// replaced as a whole. mu serializes the writers, which derive the next
+	// snapshot from the current one, and guards the fields below.
+	mu sync.Mutex
 
-	users      map[string]slack.User
-	usersInv   map[string]string
-	usersReady bool
+	users      atomic.Pointer[UsersSnapshot]
+	usersReady atomic.Bool
 
-	channels      map[string]Channel
-	channelsInv   map[string]string
-	channelsReady bool
+	channels      atomic.Pointer[ChannelsSnapshot]
+	channelsReady atomic.Bool
 
-	redisClient *RedisClient
+	// Listeners and fingerprints used to tell whether a refresh changed
+	// the users or channels directory.
+	cacheListeners      []func(CacheKind)
+	usersFingerprint    uint64
+	channelsFingerprint uint64
+
+	// cacheBackend keeps the directories between refreshes and restarts,
+	// see NewCache.
+	cacheBackend string
+
+	// unresolvedUsers remembers until when IDs Slack did not resolve are
+	// not asked for again, see ResolveUsers.
+	unresolvedUsers map[string]time.Time
+
+	// refreshMu serializes refreshes of the caches.
+	refreshMu sync.Mutex
+
+	// missMu guards lastMissRefresh, when an unknown name last triggered a
+	// refresh, and missRefresh, closed once that refresh completes and nil
+	// when none is running.
+	missMu          sync.Mutex
+	lastMissRefresh time.Time
+	missRefresh     chan struct{}
 }
 
 func NewMCPSlackClient(authProvider auth.Provider, logger *zap.Logger) (*MCPSlackClient, error) {
@@ -150,7 +199,8 @@ func NewMCPSlackClient(authProvider auth.Provider, logger *zap.Logger) (*MCPSlac
 }
 
 func (c *MCPSlackClient) AuthTest() (*slack.AuthTestResponse, error) {
-	if os.Getenv("SLACK_MCP_XOXP_TOKEN") == "demo" || (os.Getenv("SLACK_MCP_XOXC_TOKEN") == "demo" && os.Getenv("SLACK_MCP_XOXD_TOKEN") == "demo") {
+	// A nil client stands for demo credentials.
+	if c == nil {
 		return &slack.AuthTestResponse{
 			URL:          "https://_.slack.com",
 			Team:         "Demo Team",
@@ -181,10 +231,74 @@ func (c *MCPSlackClient) GetUsersInfo(users ...string) (*[]slack.User, error) {
 	return c.slackClient.GetUsersInfo(users...)
 }
 
+// UsersInfo fetches the profiles of several users in one call: users.info
+// for OAuth tokens, its edge counterpart for browser tokens, which also
+// returns users of other organizations seen in shared channels.
+func (c *MCPSlackClient) UsersInfo(ctx context.Context, userIDs ...string) ([]slack.User, error) {
+	if c.isOAuth {
+		users, err := c.slackClient.GetUsersInfoContext(ctx, userIDs...)
+		if err != nil {
+			return nil, err
+		}
+		return *users, nil
+	}
+
+	edgeUsers, err := c.edgeClient.GetUsers(ctx, userIDs...)
+	if err != nil {
+		return nil, err
+	}
+
+	users := make([]slack.User, 0, len(edgeUsers))
+	for _, eu := range edgeUsers {
+		users = append(users, slack.User{
+			ID:        eu.ID,
+			TeamID:    eu.TeamID,
+			Name:      eu.Name,
+			Color:     eu.Color,
+			Deleted:   eu.Deleted,
+			IsBot:     eu.IsBot,
+			IsAppUser: eu.IsAppUser,
+			RealName:  eu.Profile.RealName,
+			Profile: slack.UserProfile{
+				RealName:              eu.Profile.RealName,
+				RealNameNormalized:    eu.Profile.RealNameNormalized,
+				DisplayName:           eu.Profile.DisplayName,
+				DisplayNameNormalized: eu.Profile.DisplayNameNormalized,
+				Email:                 eu.Profile.Email,
+				Title:                 eu.Profile.Title,
+				StatusText:            eu.Profile.StatusText,
+				StatusEmoji:           eu.Profile.StatusEmoji,
+				Team:                  eu.Profile.Team,
+			},
+		})
+	}
+	return users, nil
+}
+
+func (c *MCPSlackClient) GetUserPresenceContext(ctx context.Context, user string) (*slack.UserPresence, error) {
+	return c.slackClient.GetUserPresenceContext(ctx, user)
+}
+
+func (c *MCPSlackClient) GetDNDTeamInfoContext(ctx context.Context, users []string) (map[string]slack.DNDStatus, error) {
+	return c.slackClient.GetDNDTeamInfoContext(ctx, users)
+}
+
 func (c *MCPSlackClient) MarkConversationContext(ctx context.Context, channel, ts string) error {
 	return c.slackClient.MarkConversationContext(ctx, channel, ts)
 }
 
+func (c *MCPSlackClient) AddReactionContext(ctx context.Context, name string, item slack.ItemRef) error {
+	return c.slackClient.AddReactionContext(ctx, name, item)
+}
+
+func (c *MCPSlackClient) RemoveReactionContext(ctx context.Context, name string, item slack.ItemRef) error {
+	return c.slackClient.RemoveReactionContext(ctx, name, item)
+}
+
+func (c *MCPSlackClient) GetReactionsContext(ctx context.Context, item slack.ItemRef, params slack.GetReactionsParameters) ([]slack.ItemReaction, error) {
+	return c.slackClient.GetReactionsContext(ctx, item, params)
+}
+
 func (c *MCPSlackClient) GetConversationsContext(ctx context.Context, params *slack.GetConversationsParameters) ([]slack.Channel, string, error) {
 	// Please see https://github.com/korotovsky/slack-mcp-server/issues/73
 	// It seems that `conversations.list` works with `xoxp` tokens within Enterprise Grid setups
@@ -242,6 +356,94 @@ func (c *MCPSlackClient) GetConversationsContext(ctx context.Context, params *sl
 	return c.slackClient.GetConversationsContext(ctx, params)
 }
 
+func (c *MCPSlackClient) GetConversationInfoContext(ctx context.Context, input *slack.GetConversationInfoInput) (*slack.Channel, error) {
+	// Same as GetConversationsContext: Enterprise Grid with browser tokens
+	// has to go through the edge client.
+	if c.isEnterprise && !c.isOAuth {
+		edgeChannel, err := c.edgeClient.GetConversationInfoContext(ctx, &rusqslack.GetConversationInfoInput{
+			ChannelID:         input.ChannelID,
+			IncludeLocale:     input.IncludeLocale,
+			IncludeNumMembers: input.IncludeNumMembers,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		// Both libraries decode the same conversations payload, so the
+		// JSON representation is the simplest lossless bridge.
+		b, err := json.Marshal(edgeChannel)
+		if err != nil {
+			return nil, err
+		}
+		var channel slack.Channel
+		if err := json.Unmarshal(b, &channel); err != nil {
+			return nil, err
+		}
+		return &channel, nil
+	}
+
+	return c.slackClient.GetConversationInfoContext(ctx, input)
+}
+
+func (c *MCPSlackClient) GetUsersInConversationContext(ctx context.Context, params *slack.GetUsersInConversationParameters) ([]string, string, error) {
+	if !c.isEnterprise || c.isOAuth {
+		return c.slackClient.GetUsersInConversationContext(ctx, params)
+	}
+
+	// The edge client returns all members at once, emulate the cursor
+	// of conversations.members with an offset.
+	ids, _, err := c.edgeClient.GetUsersInConversationContext(ctx, &rusqslack.GetUsersInConversationParameters{
+		ChannelID: params.ChannelID,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	return paginateIDs(ids, params.Cursor, params.Limit)
+}
+
+// ChannelNonMembers returns the subset of userIDs that are not members of the
+// channel. Browser sessions ask the edge channels/membership endpoint in one
+// call, OAuth tokens page through conversations.members.
+func (c *MCPSlackClient) ChannelNonMembers(ctx context.Context, channelID string, userIDs []string) ([]string, error) {
+	if !c.isOAuth {
+		resp, err := c.edgeClient.ChannelsMembership(ctx, &edge.ChannelsMembershipRequest{
+			Channel: channelID,
+			Users:   userIDs,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return resp.NonMembers, nil
+	}
+
+	pending := make(map[string]struct{}, len(userIDs))
+	for _, id := range userIDs {
+		pending[id] = struct{}{}
+	}
+	params := &slack.GetUsersInConversationParameters{ChannelID: channelID, Limit: 1000}
+	for len(pending) > 0 {
+		ids, next, err := c.slackClient.GetUsersInConversationContext(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range ids {
+			delete(pending, id)
+		}
+		if next == "" {
+			break
+		}
+		params.Cursor = next
+	}
+
+	var nonMembers []string
+	for _, id := range userIDs {
+		if _, ok := pending[id]; ok {
+			nonMembers = append(nonMembers, id)
+		}
+	}
+	return nonMembers, nil
+}
+
 func (c *MCPSlackClient) GetConversationHistoryContext(ctx context.Context, params *slack.GetConversationHistoryParameters) (*slack.GetConversationHistoryResponse, error) {
 	return c.slackClient.GetConversationHistoryContext(ctx, params)
 }
@@ -254,14 +456,56 @@ func (c *MCPSlackClient) SearchContext(ctx context.Context, query string, params
 	return c.slackClient.SearchContext(ctx, query, params)
 }
 
+func (c *MCPSlackClient) SearchFilesContext(ctx context.Context, query string, params slack.SearchParameters) (*slack.SearchFiles, error) {
+	return c.slackClient.SearchFilesContext(ctx, query, params)
+}
+
+func (c *MCPSlackClient) GetFileInfoContext(ctx context.Context, fileID string, count, page int) (*slack.File, []slack.Comment, *slack.Paging, error) {
+	return c.slackClient.GetFileInfoContext(ctx, fileID, count, page)
+}
+
+// GetFileContext downloads a private file using the same authenticated HTTP
+// client (token and browser cookies) as the rest of the API calls.
+func (c *MCPSlackClient) GetFileContext(ctx context.Context, downloadURL string, writer io.Writer) error {
+	return c.slackClient.GetFileContext(ctx, downloadURL, writer)
+}
+
 func (c *MCPSlackClient) PostMessageContext(ctx context.Context, channelID string, options ...slack.MsgOption) (string, string, error) {
 	return c.slackClient.PostMessageContext(ctx, channelID, options...)
 }
 
+func (c *MCPSlackClient) UpdateMessageContext(ctx context.Context, channelID, timestamp string, options ...slack.MsgOption) (string, string, string, error) {
+	return c.slackClient.UpdateMessageContext(ctx, channelID, timestamp, options...)
+}
+
+func (c *MCPSlackClient) DeleteMessageContext(ctx context.Context, channelID, messageTimestamp string) (string, string, error) {
+	return c.slackClient.DeleteMessageContext(ctx, channelID, messageTimestamp)
+}
+
+func (c *MCPSlackClient) ScheduleMessageContext(ctx context.Context, channelID, postAt string, options ...slack.MsgOption) (string, string, error) {
+	return c.slackClient.ScheduleMessageContext(ctx, channelID, postAt, options...)
+}
+
+func (c *MCPSlackClient) GetScheduledMessagesContext(ctx context.Context, params *slack.GetScheduledMessagesParameters) ([]slack.ScheduledMessage, string, error) {
+	return c.slackClient.GetScheduledMessagesContext(ctx, params)
+}
+
+func (c *MCPSlackClient) DeleteScheduledMessageContext(ctx context.Context, params *slack.DeleteScheduledMessageParameters) (bool, error) {
+	return c.slackClient.DeleteScheduledMessageContext(ctx, params)
+}
+
 func (c *MCPSlackClient) ClientUserBoot(ctx context.Context) (*edge.ClientUserBootResponse, error) {
 	return c.edgeClient.ClientUserBoot(ctx)
 }
 
+func (c *MCPSlackClient) ClientCounts(ctx context.Context) (edge.ClientCountsResponse, error) {
+	return c.edgeClient.ClientCounts(ctx)
+}
+
+func (c *MCPSlackClient) SubscriptionsThreadMark(ctx context.Context, channelID, threadTS, ts string) error {
+	return c.edgeClient.SubscriptionsThreadMark(ctx, channelID, threadTS, ts)
+}
+
 func (c *MCPSlackClient) IsEnterprise() bool {
 	return c.isEnterprise
 }
@@ -284,28 +528,35 @@ func (c *MCPSlackClient) Raw() struct {
 }
 
 func New(transport string, logger *zap.Logger) *ApiProvider {
+	return newFromEnv(transport, "SLACK_MCP_", logger)
+}
+
+// newFromEnv creates a provider from the tokens in the environment variables
+// starting with prefix, e.g. SLACK_MCP_ or SLACK_MCP_ACME_ for a named
+// workspace.
+func newFromEnv(transport string, prefix string, logger *zap.Logger) *ApiProvider {
 	var (
 		authProvider auth.ValueAuth
 		err          error
 	)
 
 	// Check for XOXP token first (User OAuth)
-	xoxpToken := os.Getenv("SLACK_MCP_XOXP_TOKEN")
+	xoxpToken := os.Getenv(prefix + "XOXP_TOKEN")
 	if xoxpToken != "" {
 		authProvider, err = auth.NewValueAuth(xoxpToken, "")
 		if err != nil {
 			logger.Fatal("Failed to create auth provider with XOXP token", zap.Error(err))
 		}
 
-		return newWithXOXP(transport, authProvider, logger)
+		return newWithXOXP(transport, authProvider, xoxpToken == "demo", logger)
 	}
 
 	// Fall back to XOXC/XOXD tokens (session-based)
-	xoxcToken := os.Getenv("SLACK_MCP_XOXC_TOKEN")
-	xoxdToken := os.Getenv("SLACK_MCP_XOXD_TOKEN")
+	xoxcToken := os.Getenv(prefix + "XOXC_TOKEN")
+	xoxdToken := os.Getenv(prefix + "XOXD_TOKEN")
 
 	if xoxcToken == "" || xoxdToken == "" {
-		logger.Fatal("Authentication required: Either SLACK_MCP_XOXP_TOKEN (User OAuth) or both SLACK_MCP_XOXC_TOKEN and SLACK_MCP_XOXD_TOKEN (session-based) environment variables must be provided")
+		logger.Fatal(fmt.Sprintf("Authentication required: Either %[1]sXOXP_TOKEN (User OAuth) or both %[1]sXOXC_TOKEN and %[1]sXOXD_TOKEN (session-based) environment variables must be provided", prefix))
 	}
 
 	authProvider, err = auth.NewValueAuth(xoxcToken, xoxdToken)
@@ -313,30 +564,50 @@ func New(transport string, logger *zap.Logger) *ApiProvider {
 		logger.Fatal("Failed to create auth provider with XOXC/XOXD tokens", zap.Error(err))
 	}
 
-	return newWithXOXC(transport, authProvider, logger)
+	return newWithXOXC(transport, authProvider, xoxcToken == "demo" && xoxdToken == "demo", logger)
 }
 
-// getRedisClient returns a Redis client for the given instance ID and user ID, creating it if necessary
-func (ap *ApiProvider) getRedisClient(instanceID string, userID string) (*RedisClient, error) {
-	if instanceID == "" || userID == "" {
-		return nil, nil
+// openCache opens the cache partition of the authenticated identity: the
+// enterprise or team and the user. It returns nil when caching is disabled
+// or the cache cannot be opened, refreshes then go to the API.
+func (ap *ApiProvider) openCache() Cache {
+	if ap.cacheBackend == CacheBackendNone {
+		return nil
+	}
+
+	authResp, err := ap.client.AuthTest()
+	if err != nil {
+		ap.logger.Error("Failed to get auth test for team ID", zap.Error(err))
+		return nil
 	}
 
-	// Check if Redis is configured
-	if os.Getenv("REDIS_ADDR") == "" && os.Getenv("REDIS_PASSWORD") == "" && os.Getenv("REDIS_DB") == "" {
-		return nil, nil
+	// Use enterpriseID as instanceID if available, otherwise use teamID
+	instanceID := authResp.EnterpriseID
+	if instanceID == "" {
+		instanceID = authResp.TeamID
+	}
+	if instanceID == "" || authResp.UserID == "" {
+		ap.logger.Warn("Instance ID or User ID is empty, skipping cache operations",
+			zap.String("team_id", authResp.TeamID),
+			zap.String("enterprise_id", authResp.EnterpriseID),
+			zap.String("user_id", authResp.UserID))
+		return nil
 	}
 
-	// For now, create a new client each time. In the future, we could cache clients by instanceID/userID
-	return NewRedisClient(ap.logger, instanceID, userID)
+	cache, err := NewCache(ap.cacheBackend, ap.logger, instanceID, authResp.UserID)
+	if err != nil {
+		ap.logger.Error("Failed to open cache", zap.String("backend", ap.cacheBackend), zap.Error(err))
+		return nil
+	}
+	return cache
 }
 
-func newWithXOXP(transport string, authProvider auth.ValueAuth, logger *zap.Logger) *ApiProvider {
+func newWithXOXP(transport string, authProvider auth.ValueAuth, demo bool, logger *zap.Logger) *ApiProvider {
 	var (
 		client *MCPSlackClient
 		err    error
 	)
-	if os.Getenv("SLACK_MCP_XOXP_TOKEN") == "demo" || (os.Getenv("SLACK_MCP_XOXC_TOKEN") == "demo" && os.Getenv("SLACK_MCP_XOXD_TOKEN") == "demo") {
+	if demo {
 		logger.Info("Demo credentials are set, skip.")
 	} else {
 		client, err = NewMCPSlackClient(authProvider, logger)
@@ -345,29 +616,15 @@ func newWithXOXP(transport string, authProvider auth.ValueAuth, logger *zap.Logg
 		}
 	}
 
-	return &ApiProvider{
-		transport: transport,
-		client:    client,
-		logger:    logger,
-
-		rateLimiter: limiter.Tier2.Limiter(),
-
-		users:    make(map[string]slack.User),
-		usersInv: map[string]string{},
-
-		channels:    make(map[string]Channel),
-		channelsInv: map[string]string{},
-
-		redisClient: nil,
-	}
+	return newApiProvider(transport, client, logger)
 }
 
-func newWithXOXC(transport string, authProvider auth.ValueAuth, logger *zap.Logger) *ApiProvider {
+func newWithXOXC(transport string, authProvider auth.ValueAuth, demo bool, logger *zap.Logger) *ApiProvider {
 	var (
 		client *MCPSlackClient
 		err    error
 	)
-	if os.Getenv("SLACK_MCP_XOXP_TOKEN") == "demo" || (os.Getenv("SLACK_MCP_XOXC_TOKEN") == "demo" && os.Getenv("SLACK_MCP_XOXD_TOKEN") == "demo") {
+	if demo {
 		logger.Info("Demo credentials are set, skip.")
 	} else {
 		client, err = NewMCPSlackClient(authProvider, logger)
@@ -376,83 +633,62 @@ func newWithXOXC(transport string, authProvider auth.ValueAuth, logger *zap.Logg
 		}
 	}
 
+	return newApiProvider(transport, client, logger)
+}
+
+func newApiProvider(transport string, client *MCPSlackClient, logger *zap.Logger) *ApiProvider {
+	cacheBackend, err := CacheBackendFromEnv()
+	if err != nil {
+		logger.Warn("Caching disabled", zap.Error(err))
+		cacheBackend = CacheBackendNone
+	}
+
 	return &ApiProvider{
 		transport: transport,
 		client:    client,
 		logger:    logger,
 
-		rateLimiter: limiter.Tier2.Limiter(),
-
-		users:    make(map[string]slack.User),
-		usersInv: map[string]string{},
+		rateLimiter:      limiter.Tier2.Limiter(),
+		usersInfoLimiter: limiter.Tier4.Limiter(),
 
-		channels:    make(map[string]Channel),
-		channelsInv: map[string]string{},
-
-		redisClient: nil,
+		cacheBackend: cacheBackend,
 	}
 }
 
+// RefreshUsers loads the users cache, from Redis when it holds a copy.
 func (ap *ApiProvider) RefreshUsers(ctx context.Context) error {
+	ap.refreshMu.Lock()
+	defer ap.refreshMu.Unlock()
+
+	return ap.refreshUsers(ctx, true)
+}
+
+func (ap *ApiProvider) refreshUsers(ctx context.Context, useCache bool) error {
 	var (
 		usersCounter = 0
 		optionLimit  = slack.GetUsersOptionLimit(1000)
 	)
 
-	// Get team ID, user ID, and enterprise ID for cache partitioning
-	authResp, err := ap.client.AuthTest()
-	if err != nil {
-		ap.logger.Error("Failed to get auth test for team ID", zap.Error(err))
-		return err
+	cache := ap.openCache()
+	if cache != nil {
+		defer cache.Close()
 	}
-	teamID := authResp.TeamID
-	userID := authResp.UserID
-	enterpriseID := authResp.EnterpriseID
 
-	// Use enterpriseID as instanceID if available, otherwise use teamID
-	instanceID := enterpriseID
-	if instanceID == "" {
-		instanceID = teamID
-	}
-
-	if instanceID == "" || userID == "" {
-		ap.logger.Warn("Instance ID or User ID is empty, skipping Redis cache operations",
-			zap.String("instance_id", instanceID),
-			zap.String("user_id", userID),
-			zap.String("team_id", teamID),
-			zap.String("enterprise_id", enterpriseID))
-	}
-
-	// Create Redis client once and ensure it's closed
-	var redisClient *RedisClient
-	if instanceID != "" && userID != "" {
-		redisClient, err = ap.getRedisClient(instanceID, userID)
+	// Try to load from the cache first
+	if cache != nil && useCache {
+		cachedUsers, err := cache.GetUsers(ctx)
 		if err != nil {
-			ap.logger.Error("Failed to create Redis client", zap.Error(err))
-		}
-		if redisClient != nil {
-			defer redisClient.Close() // Ensure Redis client is closed to prevent connection leaks
-		}
-	}
-
-	// Try to load from Redis cache first
-	if redisClient != nil {
-		cachedUsers, err := redisClient.GetUsers(ctx)
-		if err != nil {
-			ap.logger.Warn("Failed to get users from Redis cache", zap.Error(err))
+			ap.logger.Warn("Failed to get users from cache", zap.Error(err))
 		} else if cachedUsers != nil {
 			ap.mu.Lock()
-			for _, u := range cachedUsers {
-				ap.users[u.ID] = u
-				ap.usersInv[u.Name] = u.ID
-			}
-			ap.usersReady = true
+			ap.users.Store(ap.Users().with(cachedUsers...))
+			ap.usersReady.Store(true)
 			ap.mu.Unlock()
 
-			ap.logger.Info("Loaded users from Redis cache",
-				zap.String("instance_id", instanceID),
-				zap.String("user_id", userID),
+			ap.logger.Info("Loaded users from cache",
+				zap.String("backend", ap.cacheBackend),
 				zap.Int("count", len(cachedUsers)))
+			ap.notifyCacheChange(UsersCacheKind)
 			return nil
 		}
 	}
@@ -472,33 +708,14 @@ func (ap *ApiProvider) RefreshUsers(ctx context.Context) error {
 	}
 
 	// Prepare all user data before acquiring lock
-	allUsers := make(map[string]slack.User)
-	allUsersInv := make(map[string]string)
-
-	for _, user := range users {
-		allUsers[user.ID] = user
-		allUsersInv[user.Name] = user.ID
-		usersCounter++
-	}
-
-	for _, user := range slackConnectUsers {
-		allUsers[user.ID] = user
-		allUsersInv[user.Name] = user.ID
-		usersCounter++
-	}
-
-	// Create user list for caching
-	var list []slack.User
-	for _, u := range allUsers {
-		list = append(list, u)
-	}
-
-	// Cache to Redis
-	if redisClient != nil {
-		if err := redisClient.SetUsers(ctx, list); err != nil {
-			ap.logger.Error("Failed to cache users to Redis",
-				zap.String("instance_id", instanceID),
-				zap.String("user_id", userID),
+	usersCounter = len(users) + len(slackConnectUsers)
+	snapshot := NewUsersSnapshot(append(users, slackConnectUsers...))
+	list := snapshot.All()
+
+	if cache != nil {
+		if err := cache.SetUsers(ctx, list); err != nil {
+			ap.logger.Error("Failed to cache users",
+				zap.String("backend", ap.cacheBackend),
 				zap.Error(err))
 		}
 	}
@@ -508,90 +725,64 @@ func (ap *ApiProvider) RefreshUsers(ctx context.Context) error {
 
 	// Atomically update the shared state
 	ap.mu.Lock()
-	ap.users = allUsers
-	ap.usersInv = allUsersInv
-	ap.usersReady = true
+	if ap.usersReady.Load() {
+		ap.logDirectoryChanges(UsersCacheKind, diffDirectory(userNames(ap.Users()), userNames(snapshot)))
+	}
+	ap.users.Store(snapshot)
+	ap.usersReady.Store(true)
 	ap.mu.Unlock()
 
+	ap.notifyCacheChange(UsersCacheKind)
 	return nil
 }
 
+// RefreshChannels loads the channels cache, from Redis when it holds a copy.
 func (ap *ApiProvider) RefreshChannels(ctx context.Context) error {
-	// Get team ID, user ID, and enterprise ID for cache partitioning
-	authResp, err := ap.client.AuthTest()
-	if err != nil {
-		ap.logger.Error("Failed to get auth test for team ID", zap.Error(err))
-		return err
-	}
-	teamID := authResp.TeamID
-	userID := authResp.UserID
-	enterpriseID := authResp.EnterpriseID
+	ap.refreshMu.Lock()
+	defer ap.refreshMu.Unlock()
 
-	// Use enterpriseID as instanceID if available, otherwise use teamID
-	instanceID := enterpriseID
-	if instanceID == "" {
-		instanceID = teamID
-	}
+	return ap.refreshChannels(ctx, true)
+}
 
-	if instanceID == "" || userID == "" {
-		ap.logger.Warn("Instance ID or User ID is empty, skipping Redis cache operations",
-			zap.String("instance_id", instanceID),
-			zap.String("user_id", userID),
-			zap.String("team_id", teamID),
-			zap.String("enterprise_id", enterpriseID))
+func (ap *ApiProvider) refreshChannels(ctx context.Context, useCache bool) error {
+	cache := ap.openCache()
+	if cache != nil {
+		defer cache.Close()
 	}
 
-	// Create Redis client once and ensure it's closed
-	var redisClient *RedisClient
-	if instanceID != "" && userID != "" {
-		redisClient, err = ap.getRedisClient(instanceID, userID)
+	// Try to load from the cache first
+	if cache != nil && useCache {
+		cachedChannels, err := cache.GetChannels(ctx)
 		if err != nil {
-			ap.logger.Error("Failed to create Redis client", zap.Error(err))
-		}
-		if redisClient != nil {
-			defer redisClient.Close() // Ensure Redis client is closed to prevent connection leaks
-		}
-	}
-
-	// Try to load from Redis cache first
-	if redisClient != nil {
-		cachedChannels, err := redisClient.GetChannels(ctx)
-		if err != nil {
-			ap.logger.Warn("Failed to get channels from Redis cache", zap.Error(err))
+			ap.logger.Warn("Failed to get channels from cache", zap.Error(err))
 		} else if cachedChannels != nil {
 			ap.mu.Lock()
-			for _, c := range cachedChannels {
-				ap.channels[c.ID] = c
-				ap.channelsInv[c.Name] = c.ID
-			}
-			ap.channelsReady = true
+			ap.channels.Store(ap.Channels().with(cachedChannels...))
+			ap.channelsReady.Store(true)
 			ap.mu.Unlock()
 
-			ap.logger.Info("Loaded channels from Redis cache",
-				zap.String("instance_id", instanceID),
-				zap.String("user_id", userID),
+			ap.logger.Info("Loaded channels from cache",
+				zap.String("backend", ap.cacheBackend),
 				zap.Int("count", len(cachedChannels)))
+			ap.notifyCacheChange(ChannelsCacheKind)
 			return nil
 		}
 	}
 
-	channels := ap.GetChannels(ctx, AllChanTypes)
+	channels, err := ap.fetchChannels(ctx)
+	// A partial list would look like removed channels, keep the ones we
+	// have; the first load makes do with what it got.
+	if err != nil && ap.channelsReady.Load() {
+		return err
+	}
 
 	// Prepare channel data before acquiring lock
-	allChannels := make(map[string]Channel)
-	allChannelsInv := make(map[string]string)
-
-	for _, c := range channels {
-		allChannels[c.ID] = c
-		allChannelsInv[c.Name] = c.ID
-	}
+	snapshot := NewChannelsSnapshot(channels)
 
-	// Cache to Redis
-	if redisClient != nil {
-		if err := redisClient.SetChannels(ctx, channels); err != nil {
-			ap.logger.Error("Failed to cache channels to Redis",
-				zap.String("instance_id", instanceID),
-				zap.String("user_id", userID),
+	if cache != nil {
+		if err := cache.SetChannels(ctx, channels); err != nil {
+			ap.logger.Error("Failed to cache channels",
+				zap.String("backend", ap.cacheBackend),
 				zap.Error(err))
 		}
 	}
@@ -601,11 +792,14 @@ func (ap *ApiProvider) RefreshChannels(ctx context.Context) error {
 
 	// Atomically update the shared state
 	ap.mu.Lock()
-	ap.channels = allChannels
-	ap.channelsInv = allChannelsInv
-	ap.channelsReady = true
+	if ap.channelsReady.Load() {
+		ap.logDirectoryChanges(ChannelsCacheKind, diffDirectory(channelNames(ap.Channels()), channelNames(snapshot)))
+	}
+	ap.channels.Store(snapshot)
+	ap.channelsReady.Store(true)
 	ap.mu.Unlock()
 
+	ap.notifyCacheChange(ChannelsCacheKind)
 	return nil
 }
 
@@ -622,8 +816,7 @@ func (ap *ApiProvider) GetSlackConnect(ctx context.Context) ([]slack.User, error
 			continue
 		}
 
-		_, ok := ap.users[im.User]
-		if !ok {
+		if _, ok := ap.Users().Get(im.User); !ok {
 			collectedIDs = append(collectedIDs, im.User)
 		}
 	}
@@ -644,40 +837,31 @@ func (ap *ApiProvider) GetSlackConnect(ctx context.Context) ([]slack.User, error
 	return res, nil
 }
 
-func (ap *ApiProvider) GetChannels(ctx context.Context, channelTypes []string) []Channel {
-	if len(channelTypes) == 0 {
-		channelTypes = AllChanTypes
-	}
-
+// fetchChannels pages through every conversation of the user. On error it
+// returns the channels fetched so far along with it.
+func (ap *ApiProvider) fetchChannels(ctx context.Context) ([]Channel, error) {
 	params := &slack.GetConversationsParameters{
 		Types:           AllChanTypes,
 		Limit:           999,
 		ExcludeArchived: true,
 	}
 
-	var (
-		channels []slack.Channel
-		chans    []Channel
-
-		nextcur string
-		err     error
-	)
-
+	var chans []Channel
 	for {
 		if err := ap.rateLimiter.Wait(ctx); err != nil {
 			ap.logger.Error("Rate limiter wait failed", zap.Error(err))
-			return nil
+			return chans, err
 		}
 
-		channels, nextcur, err = ap.client.GetConversationsContext(ctx, params)
+		channels, nextcur, err := ap.client.GetConversationsContext(ctx, params)
 		if err != nil {
 			ap.logger.Error("Failed to fetch channels", zap.Error(err))
-			break
+			return chans, err
 		}
 
-		chans = make([]Channel, 0, len(channels))
+		users := ap.Users()
 		for _, channel := range channels {
-			ch := mapChannel(
+			chans = append(chans, mapChannel(
 				channel.ID,
 				channel.Name,
 				channel.NameNormalized,
@@ -689,72 +873,40 @@ func (ap *ApiProvider) GetChannels(ctx context.Context, channelTypes []string) [
 				channel.IsIM,
 				channel.IsMpIM,
 				channel.IsPrivate,
-				ap.ProvideUsersMap().Users,
-			)
-			chans = append(chans, ch)
-		}
-
-		for _, ch := range chans {
-			ap.channels[ch.ID] = ch
-			ap.channelsInv[ch.Name] = ch.ID
+				users,
+			))
 		}
+		progress.Report(ctx, float64(len(chans)), 0, fmt.Sprintf("Fetched %d channels", len(chans)))
 
 		if nextcur == "" {
-			break
+			return chans, nil
 		}
 
 		params.Cursor = nextcur
 	}
-
-	var res []Channel
-	for _, t := range channelTypes {
-		for _, channel := range ap.channels {
-			if t == "public_channel" && !channel.IsPrivate {
-				res = append(res, channel)
-			}
-			if t == "private_channel" && channel.IsPrivate {
-				res = append(res, channel)
-			}
-			if t == "im" && channel.IsIM {
-				res = append(res, channel)
-			}
-			if t == "mpim" && channel.IsMpIM {
-				res = append(res, channel)
-			}
-		}
-	}
-
-	return res
 }
 
-func (ap *ApiProvider) ProvideUsersMap() *UsersCache {
-	ap.mu.RLock()
-	defer ap.mu.RUnlock()
-
-	return &UsersCache{
-		Users:    ap.users,
-		UsersInv: ap.usersInv,
+// Users returns the current users directory.
+func (ap *ApiProvider) Users() *UsersSnapshot {
+	if s := ap.users.Load(); s != nil {
+		return s
 	}
+	return &UsersSnapshot{}
 }
 
-func (ap *ApiProvider) ProvideChannelsMaps() *ChannelsCache {
-	ap.mu.RLock()
-	defer ap.mu.RUnlock()
-
-	return &ChannelsCache{
-		Channels:    ap.channels,
-		ChannelsInv: ap.channelsInv,
+// Channels returns the current channels directory.
+func (ap *ApiProvider) Channels() *ChannelsSnapshot {
+	if s := ap.channels.Load(); s != nil {
+		return s
 	}
+	return &ChannelsSnapshot{}
 }
 
 func (ap *ApiProvider) IsReady() (bool, error) {
-	ap.mu.RLock()
-	defer ap.mu.RUnlock()
-
-	if !ap.usersReady {
+	if !ap.usersReady.Load() {
 		return false, ErrUsersNotReady
 	}
-	if !ap.channelsReady {
+	if !ap.channelsReady.Load() {
 		return false, ErrChannelsNotReady
 	}
 	return true, nil
@@ -768,12 +920,27 @@ func (ap *ApiProvider) Slack() SlackAPI {
 	return ap.client
 }
 
+// IsDemo tells whether the provider was built from demo credentials and
+// therefore has no Slack client.
+func (ap *ApiProvider) IsDemo() bool {
+	return !ap.hasClient()
+}
+
+// SlackClient returns the underlying slack-go client, which is nil when
+// running with demo credentials.
+func (ap *ApiProvider) SlackClient() *slack.Client {
+	if c, ok := ap.client.(*MCPSlackClient); ok && c != nil {
+		return c.slackClient
+	}
+	return nil
+}
+
 func mapChannel(
 	id, name, nameNormalized, topic, purpose, user string,
 	members []string,
 	numMembers int,
 	isIM, isMpIM, isPrivate bool,
-	usersMap map[string]slack.User,
+	users *UsersSnapshot,
 ) Channel {
 	channelName := name
 	finalPurpose := purpose
@@ -782,7 +949,7 @@ func mapChannel(
 
 	if isIM {
 		finalMemberCount = 2
-		if u, ok := usersMap[user]; ok {
+		if u, ok := users.Get(user); ok {
 			channelName = "@" + u.Name
 			finalPurpose = "DM with " + u.RealName
 		} else {
@@ -795,7 +962,7 @@ func mapChannel(
 			finalMemberCount = len(members)
 			var userNames []string
 			for _, uid := range members {
-				if u, ok := usersMap[uid]; ok {
+				if u, ok := users.Get(uid); ok {
 					userNames = append(userNames, u.RealName)
 				} else {
 					userNames = append(userNames, uid)
@@ -820,3 +987,31 @@ func mapChannel(
 		IsPrivate:   isPrivate,
 	}
 }
+
+// paginateIDs pages a full list of IDs the same way channels_list does: IDs
+// are sorted and the cursor is the base64 encoded last ID of the page.
+func paginateIDs(ids []string, cursor string, limit int) ([]string, string, error) {
+	sort.Strings(ids)
+
+	start := 0
+	if cursor != "" {
+		decoded, err := base64.StdEncoding.DecodeString(cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		start = sort.SearchStrings(ids, string(decoded)+"\x00")
+	}
+	if limit <= 0 {
+		limit = len(ids)
+	}
+
+	end := start + limit
+	if end > len(ids) {
+		end = len(ids)
+	}
+	var next string
+	if end < len(ids) {
+		next = base64.StdEncoding.EncodeToString([]byte(ids[end-1]))
+	}
+	return ids[start:end], next, nil
+}
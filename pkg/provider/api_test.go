@@ -0,0 +1,35 @@
+package provider
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitPaginateIDs(t *testing.T) {
+	ids := []string{"U3", "U1", "U5", "U2", "U4"}
+
+	page, next, err := paginateIDs(ids, "", 2)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"U1", "U2"}, page)
+	assert.Equal(t, base64.StdEncoding.EncodeToString([]byte("U2")), next)
+
+	page, next, err = paginateIDs(ids, next, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"U3", "U4"}, page)
+
+	page, next, err = paginateIDs(ids, next, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"U5"}, page)
+	assert.Empty(t, next)
+
+	page, next, err = paginateIDs(ids, "", 0)
+	require.NoError(t, err)
+	assert.Len(t, page, 5)
+	assert.Empty(t, next)
+
+	_, _, err = paginateIDs(ids, "%%%", 2)
+	assert.Error(t, err)
+}
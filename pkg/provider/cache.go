@@ -0,0 +1,74 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/slack-go/slack"
+	"go.uber.org/zap"
+)
+
+// Cache backends, selected with SLACK_MCP_CACHE_BACKEND.
+const (
+	CacheBackendRedis  = "redis"
+	CacheBackendFile   = "file"
+	CacheBackendMemory = "memory"
+	CacheBackendNone   = "none"
+)
+
+// Cache keeps the users and channels of one Slack identity between
+// refreshes and restarts. The getters return nil without error when
+// nothing younger than CacheTTL is stored.
+type Cache interface {
+	GetUsers(ctx context.Context) ([]slack.User, error)
+	SetUsers(ctx context.Context, users []slack.User) error
+	GetChannels(ctx context.Context) ([]Channel, error)
+	SetChannels(ctx context.Context, channels []Channel) error
+	Close() error
+}
+
+var (
+	_ Cache = (*RedisClient)(nil)
+	_ Cache = (*FileCache)(nil)
+	_ Cache = (*MemoryCache)(nil)
+)
+
+// CacheBackendFromEnv returns the backend named by SLACK_MCP_CACHE_BACKEND.
+// It defaults to Redis when one of the REDIS_* variables is set, for
+// compatibility, and to no cache otherwise: the file backend writes the
+// directory, emails and private channels included, to disk and is opt-in.
+func CacheBackendFromEnv() (string, error) {
+	backend := strings.ToLower(strings.TrimSpace(os.Getenv("SLACK_MCP_CACHE_BACKEND")))
+	switch backend {
+	case "":
+		if os.Getenv("REDIS_URL") != "" || os.Getenv("REDIS_ADDR") != "" || os.Getenv("REDIS_PASSWORD") != "" || os.Getenv("REDIS_DB") != "" {
+			return CacheBackendRedis, nil
+		}
+		return CacheBackendNone, nil
+	case CacheBackendRedis, CacheBackendFile, CacheBackendMemory, CacheBackendNone:
+		return backend, nil
+	default:
+		return "", fmt.Errorf("unknown cache backend %q, allowed: %s, %s, %s, %s",
+			backend, CacheBackendRedis, CacheBackendFile, CacheBackendMemory, CacheBackendNone)
+	}
+}
+
+// NewCache opens the cache partition of userID in instanceID, the
+// enterprise or team ID, with the given backend. It returns nil for
+// CacheBackendNone.
+func NewCache(backend string, logger *zap.Logger, instanceID string, userID string) (Cache, error) {
+	switch backend {
+	case CacheBackendRedis:
+		return NewRedisClient(logger, instanceID, userID)
+	case CacheBackendFile:
+		return NewFileCache(logger, instanceID, userID)
+	case CacheBackendMemory:
+		return NewMemoryCache(instanceID, userID), nil
+	case CacheBackendNone:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unknown cache backend %q", backend)
+	}
+}
@@ -0,0 +1,148 @@
+package provider
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/slack-go/slack"
+	"go.uber.org/zap"
+)
+
+// FileCache keeps the users and channels as gzipped JSON files in
+// SLACK_MCP_CACHE_DIR, by default slack-mcp-server in the user cache
+// directory, e.g. ~/.cache/slack-mcp-server/<instance>/<user>/users.json.gz.
+type FileCache struct {
+	dir    string
+	logger *zap.Logger
+}
+
+func NewFileCache(logger *zap.Logger, instanceID string, userID string) (*FileCache, error) {
+	root := os.Getenv("SLACK_MCP_CACHE_DIR")
+	if root == "" {
+		base, err := os.UserCacheDir()
+		if err != nil {
+			return nil, fmt.Errorf("failed to locate the user cache directory, set SLACK_MCP_CACHE_DIR: %v", err)
+		}
+		root = filepath.Join(base, "slack-mcp-server")
+	}
+
+	dir := filepath.Join(root, filepath.Base(instanceID), filepath.Base(userID))
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %v", err)
+	}
+
+	return &FileCache{dir: dir, logger: logger}, nil
+}
+
+func (f *FileCache) path(resource string) string {
+	return filepath.Join(f.dir, resource+".json.gz")
+}
+
+// load decodes the snapshot of resource into v. It reports false when there
+// is none or it is older than CacheTTL.
+func (f *FileCache) load(resource string, v any) (bool, error) {
+	file, err := os.Open(f.path(resource))
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to open %s cache: %v", resource, err)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return false, fmt.Errorf("failed to stat %s cache: %v", resource, err)
+	}
+	if time.Since(info.ModTime()) > CacheTTL {
+		return false, nil
+	}
+
+	zr, err := gzip.NewReader(file)
+	if err != nil {
+		return false, fmt.Errorf("failed to read %s cache: %v", resource, err)
+	}
+	defer zr.Close()
+
+	if err := json.NewDecoder(zr).Decode(v); err != nil {
+		return false, fmt.Errorf("failed to unmarshal %s: %v", resource, err)
+	}
+	return true, nil
+}
+
+// save writes the snapshot of resource to a temporary file and renames it
+// over the previous one, so that readers never see a partial snapshot.
+func (f *FileCache) save(resource string, v any) error {
+	tmp, err := os.CreateTemp(f.dir, resource+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create %s cache: %v", resource, err)
+	}
+	defer os.Remove(tmp.Name())
+
+	zw := gzip.NewWriter(tmp)
+	if err := json.NewEncoder(zw).Encode(v); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to marshal %s: %v", resource, err)
+	}
+	if err := zw.Close(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write %s cache: %v", resource, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write %s cache: %v", resource, err)
+	}
+
+	if err := os.Rename(tmp.Name(), f.path(resource)); err != nil {
+		return fmt.Errorf("failed to replace %s cache: %v", resource, err)
+	}
+	return nil
+}
+
+func (f *FileCache) GetUsers(ctx context.Context) ([]slack.User, error) {
+	var users []slack.User
+	if ok, err := f.load("users", &users); !ok {
+		return nil, err
+	}
+
+	f.logger.Debug("Loaded users from file", zap.String("dir", f.dir), zap.Int("count", len(users)))
+	return users, nil
+}
+
+func (f *FileCache) SetUsers(ctx context.Context, users []slack.User) error {
+	if err := f.save("users", users); err != nil {
+		return err
+	}
+
+	f.logger.Debug("Cached users to file", zap.String("dir", f.dir), zap.Int("count", len(users)))
+	return nil
+}
+
+func (f *FileCache) GetChannels(ctx context.Context) ([]Channel, error) {
+	var channels []Channel
+	if ok, err := f.load("channels", &channels); !ok {
+		return nil, err
+	}
+
+	f.logger.Debug("Loaded channels from file", zap.String("dir", f.dir), zap.Int("count", len(channels)))
+	return channels, nil
+}
+
+func (f *FileCache) SetChannels(ctx context.Context, channels []Channel) error {
+	if err := f.save("channels", channels); err != nil {
+		return err
+	}
+
+	f.logger.Debug("Cached channels to file", zap.String("dir", f.dir), zap.Int("count", len(channels)))
+	return nil
+}
+
+func (f *FileCache) Close() error {
+	return nil
+}
@@ -0,0 +1,93 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+// memoryStore holds the entries of every MemoryCache of the process, so that
+// a provider created again for the same identity, e.g. a returning HTTP
+// session, starts warm.
+var memoryStore = struct {
+	sync.Mutex
+	entries map[string]memoryEntry
+}{entries: make(map[string]memoryEntry)}
+
+type memoryEntry struct {
+	data    []byte
+	expires time.Time
+}
+
+// MemoryCache keeps the users and channels in the memory of the process.
+// Entries are stored serialized so that callers never share them.
+type MemoryCache struct {
+	instanceID string
+	userID     string
+}
+
+func NewMemoryCache(instanceID string, userID string) *MemoryCache {
+	return &MemoryCache{instanceID: instanceID, userID: userID}
+}
+
+func (m *MemoryCache) key(resource string) string {
+	return fmt.Sprintf("slack:%s/%s:%s", m.instanceID, m.userID, resource)
+}
+
+func (m *MemoryCache) get(resource string, v any) (bool, error) {
+	memoryStore.Lock()
+	e, ok := memoryStore.entries[m.key(resource)]
+	memoryStore.Unlock()
+
+	if !ok || time.Now().After(e.expires) {
+		return false, nil
+	}
+	if err := json.Unmarshal(e.data, v); err != nil {
+		return false, fmt.Errorf("failed to unmarshal %s: %v", resource, err)
+	}
+	return true, nil
+}
+
+func (m *MemoryCache) set(resource string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s: %v", resource, err)
+	}
+
+	memoryStore.Lock()
+	memoryStore.entries[m.key(resource)] = memoryEntry{data: data, expires: time.Now().Add(CacheTTL)}
+	memoryStore.Unlock()
+	return nil
+}
+
+func (m *MemoryCache) GetUsers(ctx context.Context) ([]slack.User, error) {
+	var users []slack.User
+	if ok, err := m.get("users", &users); !ok {
+		return nil, err
+	}
+	return users, nil
+}
+
+func (m *MemoryCache) SetUsers(ctx context.Context, users []slack.User) error {
+	return m.set("users", users)
+}
+
+func (m *MemoryCache) GetChannels(ctx context.Context) ([]Channel, error) {
+	var channels []Channel
+	if ok, err := m.get("channels", &channels); !ok {
+		return nil, err
+	}
+	return channels, nil
+}
+
+func (m *MemoryCache) SetChannels(ctx context.Context, channels []Channel) error {
+	return m.set("channels", channels)
+}
+
+func (m *MemoryCache) Close() error {
+	return nil
+}
@@ -0,0 +1,122 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestUnitCacheBackendFromEnv(t *testing.T) {
+	for _, key := range []string{"SLACK_MCP_CACHE_BACKEND", "REDIS_URL", "REDIS_ADDR", "REDIS_PASSWORD", "REDIS_DB"} {
+		t.Setenv(key, "")
+	}
+
+	backend, err := CacheBackendFromEnv()
+	require.NoError(t, err)
+	assert.Equal(t, CacheBackendNone, backend)
+
+	t.Setenv("REDIS_URL", "rediss://cache.example.com:6380")
+	backend, err = CacheBackendFromEnv()
+	require.NoError(t, err)
+	assert.Equal(t, CacheBackendRedis, backend)
+
+	t.Setenv("SLACK_MCP_CACHE_BACKEND", "Memory")
+	backend, err = CacheBackendFromEnv()
+	require.NoError(t, err)
+	assert.Equal(t, CacheBackendMemory, backend)
+
+	t.Setenv("SLACK_MCP_CACHE_BACKEND", "sqlite")
+	_, err = CacheBackendFromEnv()
+	assert.Error(t, err)
+}
+
+func testCacheRoundTrip(t *testing.T, cache Cache) {
+	ctx := context.Background()
+
+	users, err := cache.GetUsers(ctx)
+	require.NoError(t, err)
+	assert.Nil(t, users)
+
+	want := []slack.User{{ID: "U123", Name: "jane", Profile: slack.UserProfile{RealName: "Jane Doe"}}}
+	require.NoError(t, cache.SetUsers(ctx, want))
+	users, err = cache.GetUsers(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, want, users)
+
+	channels := []Channel{{ID: "C123", Name: "#general", Topic: "All hands", MemberCount: 42}}
+	require.NoError(t, cache.SetChannels(ctx, channels))
+	got, err := cache.GetChannels(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, channels, got)
+
+	require.NoError(t, cache.Close())
+}
+
+func TestUnitMemoryCache(t *testing.T) {
+	testCacheRoundTrip(t, NewMemoryCache("TMEMORY", "U1"))
+
+	// Another cache of the same identity sees the entries, others do not.
+	users, err := NewMemoryCache("TMEMORY", "U1").GetUsers(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, users, 1)
+	users, err = NewMemoryCache("TMEMORY", "U2").GetUsers(context.Background())
+	require.NoError(t, err)
+	assert.Nil(t, users)
+}
+
+func TestUnitFileCache(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("SLACK_MCP_CACHE_DIR", dir)
+
+	cache, err := NewFileCache(zaptest.NewLogger(t), "T123", "U456")
+	require.NoError(t, err)
+	testCacheRoundTrip(t, cache)
+
+	path := filepath.Join(dir, "T123", "U456", "users.json.gz")
+	assert.FileExists(t, path)
+
+	// Snapshots older than CacheTTL are ignored.
+	old := time.Now().Add(-CacheTTL - time.Minute)
+	require.NoError(t, os.Chtimes(path, old, old))
+	users, err := cache.GetUsers(context.Background())
+	require.NoError(t, err)
+	assert.Nil(t, users)
+
+	// A corrupted snapshot is reported rather than loaded.
+	require.NoError(t, os.WriteFile(path, []byte("not gzip"), 0o600))
+	_, err = cache.GetUsers(context.Background())
+	assert.Error(t, err)
+}
+
+func TestUnitRefreshUsesCache(t *testing.T) {
+	t.Setenv("SLACK_MCP_CACHE_BACKEND", CacheBackendFile)
+	t.Setenv("SLACK_MCP_CACHE_DIR", t.TempDir())
+
+	fake := &fakeDirectory{
+		users:    []slack.User{{ID: "U1", Name: "jane"}},
+		channels: []slack.Channel{newFakeChannel("C1", "general")},
+	}
+	first := newApiProvider("stdio", nil, zap.NewNop())
+	first.client = fake
+	require.NoError(t, first.RefreshUsers(context.Background()))
+	require.NoError(t, first.RefreshChannels(context.Background()))
+	assert.Equal(t, 1, fake.calls)
+
+	// A restart loads the snapshots instead of calling the API.
+	second := newApiProvider("stdio", nil, zap.NewNop())
+	second.client = fake
+	require.NoError(t, second.RefreshUsers(context.Background()))
+	require.NoError(t, second.RefreshChannels(context.Background()))
+	assert.Equal(t, 1, fake.calls)
+	c, ok := second.Channels().ByName("#general")
+	assert.True(t, ok)
+	assert.Equal(t, "C1", c.ID)
+}
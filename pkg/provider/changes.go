@@ -0,0 +1,189 @@
+package provider
+
+import (
+	"hash"
+	"hash/fnv"
+	"sort"
+	"strconv"
+
+	"github.com/slack-go/slack"
+	"go.uber.org/zap"
+)
+
+type CacheKind string
+
+const (
+	UsersCacheKind    CacheKind = "users"
+	ChannelsCacheKind CacheKind = "channels"
+)
+
+// OnCacheChange registers fn to be called after a refresh changed the
+// content of the users or channels directory.
+func (ap *ApiProvider) OnCacheChange(fn func(CacheKind)) {
+	ap.mu.Lock()
+	defer ap.mu.Unlock()
+
+	ap.cacheListeners = append(ap.cacheListeners, fn)
+}
+
+// notifyCacheChange fingerprints the directory of the given kind and calls
+// the listeners when it differs from the one seen on the previous refresh.
+func (ap *ApiProvider) notifyCacheChange(kind CacheKind) {
+	ap.mu.Lock()
+	var changed bool
+	switch kind {
+	case UsersCacheKind:
+		fp := usersFingerprint(ap.Users())
+		changed = fp != ap.usersFingerprint
+		ap.usersFingerprint = fp
+	case ChannelsCacheKind:
+		fp := channelsFingerprint(ap.Channels())
+		changed = fp != ap.channelsFingerprint
+		ap.channelsFingerprint = fp
+	}
+	listeners := ap.cacheListeners
+	ap.mu.Unlock()
+
+	if !changed {
+		ap.logger.Debug("Cache refreshed without changes", zap.String("kind", string(kind)))
+		return
+	}
+
+	ap.logger.Debug("Cache content changed", zap.String("kind", string(kind)), zap.Int("listeners", len(listeners)))
+	for _, fn := range listeners {
+		fn(kind)
+	}
+}
+
+// usersFingerprint hashes the fields exposed by the users directory resource.
+func usersFingerprint(users *UsersSnapshot) uint64 {
+	h := fnv.New64a()
+	for _, u := range users.All() {
+		writeFields(h, u.ID, u.Name, u.RealName)
+	}
+	return h.Sum64()
+}
+
+// channelsFingerprint hashes the fields exposed by the channels directory
+// resource.
+func channelsFingerprint(channels *ChannelsSnapshot) uint64 {
+	h := fnv.New64a()
+	for _, c := range channels.All() {
+		writeFields(h, c.ID, c.Name, c.Topic, c.Purpose, strconv.Itoa(c.MemberCount))
+	}
+	return h.Sum64()
+}
+
+func writeFields(h hash.Hash, fields ...string) {
+	for _, f := range fields {
+		h.Write([]byte(f))
+		h.Write([]byte{0})
+	}
+}
+
+// UpsertChannel adds or replaces a single channel, e.g. from a real-time
+// event, without waiting for the next full refresh.
+func (ap *ApiProvider) UpsertChannel(c Channel) {
+	ap.mu.Lock()
+	ap.channels.Store(ap.Channels().with(c))
+	ap.mu.Unlock()
+
+	ap.notifyCacheChange(ChannelsCacheKind)
+}
+
+// RemoveChannel drops a deleted channel from the cache.
+func (ap *ApiProvider) RemoveChannel(id string) {
+	ap.mu.Lock()
+	current := ap.Channels()
+	if _, ok := current.Get(id); !ok {
+		ap.mu.Unlock()
+		return
+	}
+	ap.channels.Store(current.without(id))
+	ap.mu.Unlock()
+
+	ap.notifyCacheChange(ChannelsCacheKind)
+}
+
+// UpsertUser adds or replaces a single user, e.g. from a real-time event,
+// without waiting for the next full refresh.
+func (ap *ApiProvider) UpsertUser(u slack.User) {
+	ap.upsertUsers([]slack.User{u})
+}
+
+// DirectoryChanges lists what a refresh changed in the users or channels
+// directory, by ID.
+type DirectoryChanges struct {
+	Added   []string
+	Removed []string
+	Renamed []Rename
+}
+
+// Rename is an entry of the directory whose name changed.
+type Rename struct {
+	ID   string
+	From string
+	To   string
+}
+
+func (c DirectoryChanges) IsEmpty() bool {
+	return len(c.Added) == 0 && len(c.Removed) == 0 && len(c.Renamed) == 0
+}
+
+// diffDirectory compares two maps of IDs to names.
+func diffDirectory(before, after map[string]string) DirectoryChanges {
+	var c DirectoryChanges
+	for id, name := range after {
+		old, ok := before[id]
+		switch {
+		case !ok:
+			c.Added = append(c.Added, id)
+		case old != name:
+			c.Renamed = append(c.Renamed, Rename{ID: id, From: old, To: name})
+		}
+	}
+	for id := range before {
+		if _, ok := after[id]; !ok {
+			c.Removed = append(c.Removed, id)
+		}
+	}
+
+	sort.Strings(c.Added)
+	sort.Strings(c.Removed)
+	sort.Slice(c.Renamed, func(i, j int) bool { return c.Renamed[i].ID < c.Renamed[j].ID })
+	return c
+}
+
+func userNames(users *UsersSnapshot) map[string]string {
+	names := make(map[string]string, users.Len())
+	for _, u := range users.All() {
+		names[u.ID] = u.Name
+	}
+	return names
+}
+
+func channelNames(channels *ChannelsSnapshot) map[string]string {
+	names := make(map[string]string, channels.Len())
+	for _, c := range channels.All() {
+		names[c.ID] = c.Name
+	}
+	return names
+}
+
+// logDirectoryChanges reports what a periodic or on-demand refresh changed.
+func (ap *ApiProvider) logDirectoryChanges(kind CacheKind, c DirectoryChanges) {
+	if c.IsEmpty() {
+		return
+	}
+
+	renamed := make([]string, 0, len(c.Renamed))
+	for _, r := range c.Renamed {
+		renamed = append(renamed, r.ID+": "+r.From+" -> "+r.To)
+	}
+	ap.logger.Info("Directory changed since last refresh",
+		zap.String("kind", string(kind)),
+		zap.Strings("added", c.Added),
+		zap.Strings("removed", c.Removed),
+		zap.Strings("renamed", renamed),
+	)
+}
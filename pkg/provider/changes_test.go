@@ -0,0 +1,51 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestUnitNotifyCacheChange(t *testing.T) {
+	ap := &ApiProvider{logger: zap.NewNop()}
+
+	var got []CacheKind
+	ap.OnCacheChange(func(kind CacheKind) {
+		got = append(got, kind)
+	})
+
+	ap.users.Store(NewUsersSnapshot([]slack.User{{ID: "U1", Name: "jane"}}))
+	ap.notifyCacheChange(UsersCacheKind)
+	assert.Equal(t, []CacheKind{UsersCacheKind}, got)
+
+	// Same content in a fresh snapshot is not a change.
+	ap.users.Store(NewUsersSnapshot([]slack.User{{ID: "U1", Name: "jane"}}))
+	ap.notifyCacheChange(UsersCacheKind)
+	assert.Len(t, got, 1)
+
+	// Fields that are not part of the directory are ignored.
+	ap.users.Store(NewUsersSnapshot([]slack.User{{ID: "U1", Name: "jane", TZ: "Europe/Berlin"}}))
+	ap.notifyCacheChange(UsersCacheKind)
+	assert.Len(t, got, 1)
+
+	ap.channels.Store(NewChannelsSnapshot([]Channel{{ID: "C1", Name: "#general", Topic: "old"}}))
+	ap.notifyCacheChange(ChannelsCacheKind)
+	ap.channels.Store(NewChannelsSnapshot([]Channel{{ID: "C1", Name: "#general", Topic: "new"}}))
+	ap.notifyCacheChange(ChannelsCacheKind)
+	assert.Equal(t, []CacheKind{UsersCacheKind, ChannelsCacheKind, ChannelsCacheKind}, got)
+}
+
+func TestUnitDiffDirectory(t *testing.T) {
+	before := map[string]string{"C1": "#general", "C2": "#random", "C3": "#old"}
+	after := map[string]string{"C1": "#general", "C2": "#watercooler", "C4": "#new"}
+
+	c := diffDirectory(before, after)
+	assert.Equal(t, []string{"C4"}, c.Added)
+	assert.Equal(t, []string{"C3"}, c.Removed)
+	assert.Equal(t, []Rename{{ID: "C2", From: "#random", To: "#watercooler"}}, c.Renamed)
+	assert.False(t, c.IsEmpty())
+
+	assert.True(t, diffDirectory(before, before).IsEmpty())
+}
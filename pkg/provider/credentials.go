@@ -0,0 +1,41 @@
+package provider
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/rusq/slackdump/v3/auth"
+	"go.uber.org/zap"
+)
+
+// Credentials are the Slack token of one identity: a user OAuth token
+// (xoxp-...), or a browser token (xoxc-...) together with its d cookie
+// (xoxd-...).
+type Credentials struct {
+	Token  string `json:"token"`
+	Cookie string `json:"cookie,omitempty"`
+}
+
+// Fingerprint identifies the credentials without revealing them, e.g. as a
+// cache key or in logs.
+func (c Credentials) Fingerprint() string {
+	sum := sha256.Sum256([]byte(c.Token + "\x00" + c.Cookie))
+	return hex.EncodeToString(sum[:])
+}
+
+// NewWithCredentials creates a provider for credentials supplied at runtime,
+// e.g. by an HTTP client. Unlike New it reports invalid credentials instead
+// of stopping the server, and the server-wide SLACK_MCP_* tokens, demo ones
+// included, play no part.
+func NewWithCredentials(transport string, creds Credentials, logger *zap.Logger) (*ApiProvider, error) {
+	authProvider, err := auth.NewValueAuth(creds.Token, creds.Cookie)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := NewMCPSlackClient(authProvider, logger)
+	if err != nil {
+		return nil, err
+	}
+	return newApiProvider(transport, client, logger), nil
+}
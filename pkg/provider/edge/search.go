@@ -4,11 +4,13 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"log/slog"
 	"runtime/trace"
 
 	"github.com/google/uuid"
 	"github.com/korotovsky/slack-mcp-server/pkg/limiter"
+	"github.com/korotovsky/slack-mcp-server/pkg/progress"
 	"github.com/rusq/slack"
 )
 
@@ -175,6 +177,7 @@ func (cl *Client) SearchChannels(ctx context.Context, query string) ([]slack.Cha
 
 			cc = append(cc, obj)
 		}
+		progress.Report(ctx, float64(len(cc)), 0, fmt.Sprintf("Fetched %d channels", len(cc)))
 		if sr.Pagination.NextCursor == "" {
 			lg.Debug("no more channels")
 			break
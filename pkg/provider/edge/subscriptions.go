@@ -0,0 +1,44 @@
+package edge
+
+import (
+	"context"
+	"runtime/trace"
+)
+
+// subscriptions.* API
+
+type subscriptionsThreadMarkForm struct {
+	BaseRequest
+	Channel  string `json:"channel"`
+	ThreadTS string `json:"thread_ts"`
+	TS       string `json:"ts"`
+	Read     int    `json:"read"`
+	WebClientFields
+}
+
+// SubscriptionsThreadMark moves the read cursor of a thread the user is
+// subscribed to.  Unlike conversations.mark, it is only available to
+// browser session tokens.
+func (cl *Client) SubscriptionsThreadMark(ctx context.Context, channelID, threadTS, ts string) error {
+	ctx, task := trace.NewTask(ctx, "SubscriptionsThreadMark")
+	defer task.End()
+	trace.Logf(ctx, "params", "channelID=%v, threadTS=%v, ts=%v", channelID, threadTS, ts)
+
+	form := subscriptionsThreadMarkForm{
+		BaseRequest:     BaseRequest{Token: cl.token},
+		Channel:         channelID,
+		ThreadTS:        threadTS,
+		TS:              ts,
+		Read:            1,
+		WebClientFields: webclientReason("threads-view/markRead"),
+	}
+	resp, err := cl.PostForm(ctx, "subscriptions.thread.mark", values(form, true))
+	if err != nil {
+		return err
+	}
+	var r baseResponse
+	if err := cl.ParseResponse(&r, resp); err != nil {
+		return err
+	}
+	return r.validate("subscriptions.thread.mark")
+}
@@ -0,0 +1,150 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// DefaultRefreshInterval is how often the caches are reloaded when
+// SLACK_MCP_CACHE_REFRESH_INTERVAL is not set.
+const DefaultRefreshInterval = time.Hour
+
+// missRefreshInterval is the minimum time between two refreshes triggered
+// by names missing from the caches, so that a client guessing names does
+// not hammer the Slack API.
+const missRefreshInterval = time.Minute
+
+// missRefreshWait is how long a lookup missing a name waits for the refresh
+// it triggered. A large workspace takes minutes to page through, and the
+// refresh goes on in the background for later calls.
+const missRefreshWait = 10 * time.Second
+
+// Refresh reloads users and channels from the Slack API, bypassing the
+// Redis copy, and logs what changed.
+func (ap *ApiProvider) Refresh(ctx context.Context) error {
+	ap.refreshMu.Lock()
+	defer ap.refreshMu.Unlock()
+
+	return ap.refresh(ctx)
+}
+
+func (ap *ApiProvider) refresh(ctx context.Context) error {
+	if err := ap.refreshUsers(ctx, false); err != nil {
+		return err
+	}
+	return ap.refreshChannels(ctx, false)
+}
+
+// RefreshIntervalFromEnv returns the interval of RunRefresh set by
+// SLACK_MCP_CACHE_REFRESH_INTERVAL, 0 meaning no periodic refresh.
+func RefreshIntervalFromEnv() (time.Duration, error) {
+	raw := os.Getenv("SLACK_MCP_CACHE_REFRESH_INTERVAL")
+	if raw == "" {
+		return DefaultRefreshInterval, nil
+	}
+	interval, err := time.ParseDuration(raw)
+	if err != nil || interval < 0 {
+		return 0, fmt.Errorf("invalid interval %q, expected a duration such as 30m or 2h, 0 to disable", raw)
+	}
+	return interval, nil
+}
+
+// RunRefresh refreshes the caches every interval, give or take 10% so that
+// several workspaces or replicas do not hit the API at the same time. It
+// returns when ctx is done, and right away in demo mode.
+func (ap *ApiProvider) RunRefresh(ctx context.Context, interval time.Duration) {
+	if interval <= 0 || !ap.hasClient() {
+		return
+	}
+
+	for {
+		wait := interval + time.Duration((rand.Float64()*0.2-0.1)*float64(interval))
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+
+		ap.logger.Debug("Refreshing users and channels", zap.Duration("interval", interval))
+		if err := ap.Refresh(ctx); err != nil {
+			ap.logger.Error("Periodic cache refresh failed", zap.Error(err))
+		}
+	}
+}
+
+// RefreshOnMiss refreshes the caches after a user or channel name was not
+// found in them, e.g. because it was created or renamed since the last
+// refresh. The refresh runs in the background, at most one per
+// missRefreshInterval, and callers missing a name meanwhile share it. It
+// waits for the refresh until ctx is done or for missRefreshWait, and
+// reports whether the refresh completed so that the lookup is worth
+// retrying: never before the initial load completed nor in demo mode.
+func (ap *ApiProvider) RefreshOnMiss(ctx context.Context) bool {
+	if !ap.hasClient() {
+		return false
+	}
+	if ready, _ := ap.IsReady(); !ready {
+		return false
+	}
+
+	done := ap.startMissRefresh()
+	if done == nil {
+		return false
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, missRefreshWait)
+	defer cancel()
+	select {
+	case <-done:
+		return true
+	case <-ctx.Done():
+		ap.logger.Debug("Stopped waiting for the cache refresh for an unknown name", zap.Error(ctx.Err()))
+		return false
+	}
+}
+
+// startMissRefresh returns a channel closed once the running refresh for
+// an unknown name completes, starting one unless the previous one started
+// less than missRefreshInterval ago, in which case it returns nil.
+func (ap *ApiProvider) startMissRefresh() <-chan struct{} {
+	ap.missMu.Lock()
+	defer ap.missMu.Unlock()
+
+	if ap.missRefresh != nil {
+		return ap.missRefresh
+	}
+	if time.Since(ap.lastMissRefresh) < missRefreshInterval {
+		return nil
+	}
+
+	done := make(chan struct{})
+	ap.missRefresh = done
+	ap.lastMissRefresh = time.Now()
+
+	go func() {
+		ap.logger.Info("Refreshing users and channels for an unknown name")
+		// The refresh is shared by every caller missing a name, so it must
+		// not be cancelled along with the request that started it.
+		if err := ap.Refresh(context.Background()); err != nil {
+			ap.logger.Error("Cache refresh for an unknown name failed", zap.Error(err))
+		}
+
+		ap.missMu.Lock()
+		ap.missRefresh = nil
+		ap.missMu.Unlock()
+		close(done)
+	}()
+	return done
+}
+
+// hasClient tells whether the provider talks to Slack, which it does not
+// in demo mode.
+func (ap *ApiProvider) hasClient() bool {
+	c, ok := ap.client.(*MCPSlackClient)
+	return ap.client != nil && (!ok || c != nil)
+}
@@ -0,0 +1,159 @@
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider/edge"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// fakeDirectory serves the users and channels lists of a workspace, the
+// rest of SlackAPI is left unimplemented.
+type fakeDirectory struct {
+	SlackAPI
+
+	users    []slack.User
+	channels []slack.Channel
+	calls    int
+	// release, when set, holds GetUsersContext until it is closed.
+	release chan struct{}
+	// batches records the IDs of every UsersInfo call.
+	batches [][]string
+}
+
+func (f *fakeDirectory) AuthTest() (*slack.AuthTestResponse, error) {
+	return &slack.AuthTestResponse{TeamID: "T1", UserID: "U1"}, nil
+}
+
+func (f *fakeDirectory) GetUsersContext(context.Context, ...slack.GetUsersOption) ([]slack.User, error) {
+	if f.release != nil {
+		<-f.release
+	}
+	f.calls++
+	return f.users, nil
+}
+
+func (f *fakeDirectory) UsersInfo(_ context.Context, userIDs ...string) ([]slack.User, error) {
+	f.batches = append(f.batches, userIDs)
+
+	var found []slack.User
+	for _, id := range userIDs {
+		for _, u := range f.users {
+			if u.ID == id {
+				found = append(found, u)
+			}
+		}
+	}
+	return found, nil
+}
+
+func (f *fakeDirectory) ClientUserBoot(context.Context) (*edge.ClientUserBootResponse, error) {
+	return &edge.ClientUserBootResponse{}, nil
+}
+
+func (f *fakeDirectory) GetConversationsContext(context.Context, *slack.GetConversationsParameters) ([]slack.Channel, string, error) {
+	return f.channels, "", nil
+}
+
+func newFakeChannel(id, name string) slack.Channel {
+	var c slack.Channel
+	c.ID = id
+	c.Name = name
+	c.NameNormalized = name
+	return c
+}
+
+func TestUnitRefreshOnMiss(t *testing.T) {
+	t.Setenv("SLACK_MCP_CACHE_BACKEND", CacheBackendNone)
+	fake := &fakeDirectory{
+		users:    []slack.User{{ID: "U1", Name: "jane"}},
+		channels: []slack.Channel{newFakeChannel("C1", "general")},
+	}
+	ap := newApiProvider("stdio", nil, zap.NewNop())
+
+	// Nothing to refresh without a Slack client, e.g. in demo mode.
+	assert.False(t, ap.RefreshOnMiss(context.Background()))
+
+	ap.client = fake
+	// Nor before the initial load.
+	assert.False(t, ap.RefreshOnMiss(context.Background()))
+
+	require.NoError(t, ap.RefreshUsers(context.Background()))
+	require.NoError(t, ap.RefreshChannels(context.Background()))
+	assert.Equal(t, 1, fake.calls)
+
+	fake.users = append(fake.users, slack.User{ID: "U2", Name: "john"})
+	fake.channels = []slack.Channel{newFakeChannel("C1", "general"), newFakeChannel("C2", "launch")}
+
+	assert.True(t, ap.RefreshOnMiss(context.Background()))
+	assert.Equal(t, 2, fake.calls)
+	u, _ := ap.Users().ByName("john")
+	assert.Equal(t, "U2", u.ID)
+	c, _ := ap.Channels().ByName("#launch")
+	assert.Equal(t, "C2", c.ID)
+
+	// Another miss right after does not hit the API again.
+	assert.False(t, ap.RefreshOnMiss(context.Background()))
+	assert.Equal(t, 2, fake.calls)
+
+	// A cancelled request stops waiting while the refresh goes on, and the
+	// next miss waits for that refresh instead of starting another one.
+	ap.missMu.Lock()
+	ap.lastMissRefresh = time.Time{}
+	ap.missMu.Unlock()
+	fake.release = make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.False(t, ap.RefreshOnMiss(ctx))
+
+	result := make(chan bool)
+	go func() { result <- ap.RefreshOnMiss(context.Background()) }()
+	close(fake.release)
+	assert.True(t, <-result)
+	assert.Equal(t, 3, fake.calls)
+}
+
+func TestUnitRefreshDropsRemovedChannels(t *testing.T) {
+	t.Setenv("SLACK_MCP_CACHE_BACKEND", CacheBackendNone)
+	fake := &fakeDirectory{
+		channels: []slack.Channel{newFakeChannel("C1", "general"), newFakeChannel("C2", "random")},
+	}
+	ap := newApiProvider("stdio", nil, zap.NewNop())
+	ap.client = fake
+
+	require.NoError(t, ap.Refresh(context.Background()))
+	fake.channels = []slack.Channel{newFakeChannel("C1", "general")}
+	require.NoError(t, ap.Refresh(context.Background()))
+
+	assert.Equal(t, 1, ap.Channels().Len())
+	_, ok := ap.Channels().ByName("#random")
+	assert.False(t, ok)
+}
+
+func TestUnitRefreshIntervalFromEnv(t *testing.T) {
+	t.Setenv("SLACK_MCP_CACHE_REFRESH_INTERVAL", "")
+	interval, err := RefreshIntervalFromEnv()
+	require.NoError(t, err)
+	assert.Equal(t, DefaultRefreshInterval, interval)
+
+	t.Setenv("SLACK_MCP_CACHE_REFRESH_INTERVAL", "30m")
+	interval, err = RefreshIntervalFromEnv()
+	require.NoError(t, err)
+	assert.Equal(t, 30*time.Minute, interval)
+
+	t.Setenv("SLACK_MCP_CACHE_REFRESH_INTERVAL", "0")
+	interval, err = RefreshIntervalFromEnv()
+	require.NoError(t, err)
+	assert.Zero(t, interval)
+
+	for _, raw := range []string{"-1h", "hourly"} {
+		t.Setenv("SLACK_MCP_CACHE_REFRESH_INTERVAL", raw)
+		_, err = RefreshIntervalFromEnv()
+		assert.Error(t, err, raw)
+	}
+}
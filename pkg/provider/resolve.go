@@ -0,0 +1,129 @@
+package provider
+
+import (
+	"context"
+	"regexp"
+	"time"
+
+	"github.com/slack-go/slack"
+	"go.uber.org/zap"
+)
+
+const (
+	// usersInfoBatchSize bounds the IDs fetched by one users.info call.
+	usersInfoBatchSize = 100
+	// unresolvedUserTTL is how long an ID Slack did not resolve, e.g. of a
+	// deactivated external user, is not asked for again.
+	unresolvedUserTTL = 15 * time.Minute
+)
+
+var userIDRe = regexp.MustCompile(`^[UW][A-Z0-9]+$`)
+
+// ResolveUsers returns the users map after adding the users of ids it did
+// not know yet: Slack Connect users, new hires or anybody before the
+// initial load completed. They are fetched in batches of up to
+// usersInfoBatchSize and merged into the cache, so that a result set costs
+// one call instead of one per unknown row. IDs that are not user IDs, such
+// as bot IDs, are ignored. Failures leave the IDs unresolved.
+func (ap *ApiProvider) ResolveUsers(ctx context.Context, ids []string) *UsersSnapshot {
+	missing := ap.missingUsers(ids)
+	if len(missing) == 0 || !ap.hasClient() {
+		return ap.Users()
+	}
+
+	for start := 0; start < len(missing); start += usersInfoBatchSize {
+		batch := missing[start:min(start+usersInfoBatchSize, len(missing))]
+
+		if err := ap.usersInfoLimiter.Wait(ctx); err != nil {
+			ap.logger.Warn("Rate limiter wait failed", zap.Error(err))
+			break
+		}
+		users, err := ap.client.UsersInfo(ctx, batch...)
+		if err != nil {
+			// Likely one unknown ID failing the whole batch, do not retry
+			// them on every request.
+			ap.logger.Warn("Failed to resolve unknown users", zap.Strings("ids", batch), zap.Error(err))
+			ap.markUnresolved(batch)
+			continue
+		}
+
+		ap.logger.Debug("Resolved unknown users", zap.Int("requested", len(batch)), zap.Int("found", len(users)))
+		ap.upsertUsers(users)
+
+		known := make(map[string]bool, len(users))
+		for _, u := range users {
+			known[u.ID] = true
+		}
+		var unresolved []string
+		for _, id := range batch {
+			if !known[id] {
+				unresolved = append(unresolved, id)
+			}
+		}
+		ap.markUnresolved(unresolved)
+	}
+
+	return ap.Users()
+}
+
+// missingUsers returns the distinct user IDs among ids that are neither in
+// the cache nor recently failed to resolve.
+func (ap *ApiProvider) missingUsers(ids []string) []string {
+	users := ap.Users()
+
+	ap.mu.Lock()
+	defer ap.mu.Unlock()
+
+	now := time.Now()
+	seen := make(map[string]bool)
+	var missing []string
+	for _, id := range ids {
+		if seen[id] || !userIDRe.MatchString(id) {
+			continue
+		}
+		seen[id] = true
+		if _, ok := users.Get(id); ok {
+			continue
+		}
+		if until, ok := ap.unresolvedUsers[id]; ok && now.Before(until) {
+			continue
+		}
+		missing = append(missing, id)
+	}
+	return missing
+}
+
+func (ap *ApiProvider) markUnresolved(ids []string) {
+	if len(ids) == 0 {
+		return
+	}
+
+	ap.mu.Lock()
+	defer ap.mu.Unlock()
+
+	now := time.Now()
+	if ap.unresolvedUsers == nil {
+		ap.unresolvedUsers = make(map[string]time.Time)
+	}
+	for id, until := range ap.unresolvedUsers {
+		if now.After(until) {
+			delete(ap.unresolvedUsers, id)
+		}
+	}
+	for _, id := range ids {
+		ap.unresolvedUsers[id] = now.Add(unresolvedUserTTL)
+	}
+}
+
+// upsertUsers adds or replaces several users at once, see UpsertUser.
+func (ap *ApiProvider) upsertUsers(list []slack.User) {
+	if len(list) == 0 {
+		return
+	}
+
+	ap.mu.Lock()
+	ap.users.Store(ap.Users().with(list...))
+	ap.mu.Unlock()
+
+	ap.notifyCacheChange(UsersCacheKind)
+}
@@ -0,0 +1,76 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+)
+
+func TestUnitResolveUsers(t *testing.T) {
+	fake := &fakeDirectory{
+		users: []slack.User{
+			{ID: "U1", Name: "jane"},
+			{ID: "W2", Name: "ext.partner"},
+		},
+	}
+	ap := newApiProvider("stdio", nil, zap.NewNop())
+	ap.client = fake
+	ap.UpsertUser(slack.User{ID: "U0", Name: "known"})
+
+	users := ap.ResolveUsers(context.Background(), []string{"U0", "U1", "W2", "U1", "B123", "", "U404"})
+	assert.Equal(t, [][]string{{"U1", "W2", "U404"}}, fake.batches, "one batch of unknown user IDs only")
+	u, _ := users.Get("U1")
+	assert.Equal(t, "jane", u.Name)
+	u, _ = users.ByName("ext.partner")
+	assert.Equal(t, "W2", u.ID)
+	u, _ = users.Get("U0")
+	assert.Equal(t, "known", u.Name)
+
+	// Resolved users are cached and U404 is not asked for again for a while.
+	ap.ResolveUsers(context.Background(), []string{"U1", "W2", "U404"})
+	assert.Len(t, fake.batches, 1)
+}
+
+func TestUnitResolveUsersBatches(t *testing.T) {
+	fake := &fakeDirectory{}
+	ap := newApiProvider("stdio", nil, zap.NewNop())
+	ap.client = fake
+
+	ids := make([]string, 0, usersInfoBatchSize+20)
+	for i := 0; i < usersInfoBatchSize+20; i++ {
+		ids = append(ids, fmt.Sprintf("U%04d", i))
+	}
+	ap.ResolveUsers(context.Background(), ids)
+
+	if assert.Len(t, fake.batches, 2) {
+		assert.Len(t, fake.batches[0], usersInfoBatchSize)
+		assert.Len(t, fake.batches[1], 20)
+	}
+}
+
+func TestUnitResolveUsersOwnLimiter(t *testing.T) {
+	fake := &fakeDirectory{users: []slack.User{{ID: "U1", Name: "jane"}}}
+	ap := newApiProvider("stdio", nil, zap.NewNop())
+	ap.client = fake
+	// A channel refresh holding every token of the listing limiter.
+	ap.rateLimiter = rate.NewLimiter(rate.Every(time.Hour), 1)
+	ap.rateLimiter.Allow()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	users := ap.ResolveUsers(ctx, []string{"U1"})
+	u, _ := users.Get("U1")
+	assert.Equal(t, "jane", u.Name)
+}
+
+func TestUnitResolveUsersDemo(t *testing.T) {
+	ap := newApiProvider("stdio", nil, zap.NewNop())
+	users := ap.ResolveUsers(context.Background(), []string{"U1"})
+	assert.Zero(t, users.Len())
+}
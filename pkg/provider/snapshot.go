@@ -0,0 +1,230 @@
+package provider
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/slack-go/slack"
+)
+
+// UsersSnapshot is the users directory at one point in time. It is never
+// modified once published: updates publish a new snapshot, so readers can
+// keep using theirs without locking. The zero value and nil are empty.
+type UsersSnapshot struct {
+	byID        map[string]slack.User
+	byName      map[string]string
+	byLowerName map[string]string
+	byEmail     map[string]string
+}
+
+// NewUsersSnapshot indexes users. Later entries win over earlier ones with
+// the same ID.
+func NewUsersSnapshot(users []slack.User) *UsersSnapshot {
+	byID := make(map[string]slack.User, len(users))
+	for _, u := range users {
+		byID[u.ID] = u
+	}
+	return newUsersSnapshot(byID)
+}
+
+func newUsersSnapshot(byID map[string]slack.User) *UsersSnapshot {
+	s := &UsersSnapshot{
+		byID:        byID,
+		byName:      make(map[string]string, len(byID)),
+		byLowerName: make(map[string]string, len(byID)),
+		byEmail:     make(map[string]string),
+	}
+	// Index in ID order so that names shared by several users, e.g. a
+	// deleted account and its successor, resolve the same way every time.
+	for _, id := range sortedKeys(byID) {
+		u := byID[id]
+		if u.Name != "" {
+			s.byName[u.Name] = id
+			s.byLowerName[strings.ToLower(u.Name)] = id
+		}
+		if u.Profile.Email != "" {
+			s.byEmail[strings.ToLower(u.Profile.Email)] = id
+		}
+	}
+	return s
+}
+
+// with returns a copy of s with users added or replaced.
+func (s *UsersSnapshot) with(users ...slack.User) *UsersSnapshot {
+	byID := make(map[string]slack.User, s.Len()+len(users))
+	if s != nil {
+		for id, u := range s.byID {
+			byID[id] = u
+		}
+	}
+	for _, u := range users {
+		byID[u.ID] = u
+	}
+	return newUsersSnapshot(byID)
+}
+
+// Get returns the user with the given ID.
+func (s *UsersSnapshot) Get(id string) (slack.User, bool) {
+	if s == nil {
+		return slack.User{}, false
+	}
+	u, ok := s.byID[id]
+	return u, ok
+}
+
+// ByName returns the user with the given handle, without the @.
+func (s *UsersSnapshot) ByName(name string) (slack.User, bool) {
+	if s == nil {
+		return slack.User{}, false
+	}
+	return s.Get(s.byName[name])
+}
+
+// ByNameFold is ByName ignoring case.
+func (s *UsersSnapshot) ByNameFold(name string) (slack.User, bool) {
+	if s == nil {
+		return slack.User{}, false
+	}
+	return s.Get(s.byLowerName[strings.ToLower(name)])
+}
+
+// ByEmail returns the user with the given profile email, ignoring case.
+func (s *UsersSnapshot) ByEmail(email string) (slack.User, bool) {
+	if s == nil {
+		return slack.User{}, false
+	}
+	return s.Get(s.byEmail[strings.ToLower(email)])
+}
+
+func (s *UsersSnapshot) Len() int {
+	if s == nil {
+		return 0
+	}
+	return len(s.byID)
+}
+
+// All returns the users ordered by ID. The slice is the caller's.
+func (s *UsersSnapshot) All() []slack.User {
+	if s == nil {
+		return nil
+	}
+	users := make([]slack.User, 0, len(s.byID))
+	for _, id := range sortedKeys(s.byID) {
+		users = append(users, s.byID[id])
+	}
+	return users
+}
+
+// ChannelsSnapshot is the channels directory at one point in time, see
+// UsersSnapshot. Names carry their # or @ prefix.
+type ChannelsSnapshot struct {
+	byID        map[string]Channel
+	byName      map[string]string
+	byLowerName map[string]string
+}
+
+// NewChannelsSnapshot indexes channels. Later entries win over earlier
+// ones with the same ID.
+func NewChannelsSnapshot(channels []Channel) *ChannelsSnapshot {
+	byID := make(map[string]Channel, len(channels))
+	for _, c := range channels {
+		byID[c.ID] = c
+	}
+	return newChannelsSnapshot(byID)
+}
+
+func newChannelsSnapshot(byID map[string]Channel) *ChannelsSnapshot {
+	s := &ChannelsSnapshot{
+		byID:        byID,
+		byName:      make(map[string]string, len(byID)),
+		byLowerName: make(map[string]string, len(byID)),
+	}
+	for _, id := range sortedKeys(byID) {
+		c := byID[id]
+		if c.Name != "" {
+			s.byName[c.Name] = id
+			s.byLowerName[strings.ToLower(c.Name)] = id
+		}
+	}
+	return s
+}
+
+// with returns a copy of s with channels added or replaced.
+func (s *ChannelsSnapshot) with(channels ...Channel) *ChannelsSnapshot {
+	byID := make(map[string]Channel, s.Len()+len(channels))
+	if s != nil {
+		for id, c := range s.byID {
+			byID[id] = c
+		}
+	}
+	for _, c := range channels {
+		byID[c.ID] = c
+	}
+	return newChannelsSnapshot(byID)
+}
+
+// without returns a copy of s without the channel id.
+func (s *ChannelsSnapshot) without(id string) *ChannelsSnapshot {
+	byID := make(map[string]Channel, s.Len())
+	if s != nil {
+		for cid, c := range s.byID {
+			if cid != id {
+				byID[cid] = c
+			}
+		}
+	}
+	return newChannelsSnapshot(byID)
+}
+
+// Get returns the channel with the given ID.
+func (s *ChannelsSnapshot) Get(id string) (Channel, bool) {
+	if s == nil {
+		return Channel{}, false
+	}
+	c, ok := s.byID[id]
+	return c, ok
+}
+
+// ByName returns the channel with the given #channel or @user_dm name.
+func (s *ChannelsSnapshot) ByName(name string) (Channel, bool) {
+	if s == nil {
+		return Channel{}, false
+	}
+	return s.Get(s.byName[name])
+}
+
+// ByNameFold is ByName ignoring case.
+func (s *ChannelsSnapshot) ByNameFold(name string) (Channel, bool) {
+	if s == nil {
+		return Channel{}, false
+	}
+	return s.Get(s.byLowerName[strings.ToLower(name)])
+}
+
+func (s *ChannelsSnapshot) Len() int {
+	if s == nil {
+		return 0
+	}
+	return len(s.byID)
+}
+
+// All returns the channels ordered by ID. The slice is the caller's.
+func (s *ChannelsSnapshot) All() []Channel {
+	if s == nil {
+		return nil
+	}
+	channels := make([]Channel, 0, len(s.byID))
+	for _, id := range sortedKeys(s.byID) {
+		channels = append(channels, s.byID[id])
+	}
+	return channels
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
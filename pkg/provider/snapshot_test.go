@@ -0,0 +1,61 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnitUsersSnapshot(t *testing.T) {
+	users := NewUsersSnapshot([]slack.User{
+		{ID: "U2", Name: "Jane.Doe", Profile: slack.UserProfile{Email: "Jane@Example.com"}},
+		{ID: "U1", Name: "bob"},
+	})
+
+	u, ok := users.ByName("Jane.Doe")
+	assert.True(t, ok)
+	assert.Equal(t, "U2", u.ID)
+	_, ok = users.ByName("jane.doe")
+	assert.False(t, ok)
+	u, _ = users.ByNameFold("jane.doe")
+	assert.Equal(t, "U2", u.ID)
+	u, _ = users.ByEmail("jane@example.COM")
+	assert.Equal(t, "U2", u.ID)
+	_, ok = users.ByEmail("")
+	assert.False(t, ok)
+	assert.Equal(t, []string{"U1", "U2"}, []string{users.All()[0].ID, users.All()[1].ID})
+
+	// Updates leave published snapshots alone.
+	renamed := users.with(slack.User{ID: "U1", Name: "robert"})
+	_, ok = renamed.ByName("bob")
+	assert.False(t, ok)
+	u, _ = renamed.ByName("robert")
+	assert.Equal(t, "U1", u.ID)
+	u, _ = users.ByName("bob")
+	assert.Equal(t, "U1", u.ID)
+
+	var empty *UsersSnapshot
+	_, ok = empty.Get("U1")
+	assert.False(t, ok)
+	assert.Zero(t, empty.Len())
+}
+
+func TestUnitChannelsSnapshot(t *testing.T) {
+	channels := NewChannelsSnapshot([]Channel{
+		{ID: "C1", Name: "#General"},
+		{ID: "D1", Name: "@jane", IsIM: true},
+	})
+
+	c, ok := channels.ByName("#General")
+	assert.True(t, ok)
+	assert.Equal(t, "C1", c.ID)
+	c, _ = channels.ByNameFold("#general")
+	assert.Equal(t, "C1", c.ID)
+
+	removed := channels.without("C1")
+	assert.Equal(t, 1, removed.Len())
+	_, ok = removed.ByName("#General")
+	assert.False(t, ok)
+	assert.Equal(t, 2, channels.Len())
+}
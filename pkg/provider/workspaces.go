@@ -0,0 +1,127 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+var workspaceNameRe = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]*$`)
+
+// Workspace is a configured Slack workspace and its provider. Name is empty
+// when the server runs with the single, unnamed set of tokens.
+type Workspace struct {
+	Name     string
+	Provider *ApiProvider
+}
+
+// Workspaces holds the workspaces the server was configured with, in
+// configuration order. The first one is the default. With per-session
+// credentials there are none: every HTTP session brings its own.
+type Workspaces struct {
+	transport  string
+	list       []Workspace
+	perSession bool
+}
+
+// NewWorkspaces reads SLACK_MCP_WORKSPACES, a comma-separated list of names,
+// and creates a provider for each from SLACK_MCP_<NAME>_XOXP_TOKEN or
+// SLACK_MCP_<NAME>_XOXC_TOKEN and SLACK_MCP_<NAME>_XOXD_TOKEN. Without it the
+// only workspace is the one configured by SLACK_MCP_XOXP_TOKEN and friends.
+//
+// SLACK_MCP_SESSION_CREDENTIALS=true replaces all of them with credentials
+// supplied by each HTTP session, see NewWithCredentials.
+func NewWorkspaces(transport string, logger *zap.Logger) *Workspaces {
+	if perSession := os.Getenv("SLACK_MCP_SESSION_CREDENTIALS"); perSession == "true" || perSession == "1" {
+		if transport == "stdio" {
+			logger.Fatal("SLACK_MCP_SESSION_CREDENTIALS requires the sse or http transport")
+		}
+		return &Workspaces{transport: transport, perSession: true}
+	}
+
+	raw := os.Getenv("SLACK_MCP_WORKSPACES")
+	if strings.TrimSpace(raw) == "" {
+		return NewWorkspacesOf(Workspace{Provider: New(transport, logger)})
+	}
+
+	names, err := ParseWorkspaceNames(raw)
+	if err != nil {
+		logger.Fatal("Invalid SLACK_MCP_WORKSPACES", zap.Error(err))
+	}
+
+	list := make([]Workspace, 0, len(names))
+	for _, name := range names {
+		logger.Info("Configuring workspace", zap.String("context", "console"), zap.String("workspace", name))
+		list = append(list, Workspace{
+			Name:     name,
+			Provider: newFromEnv(transport, WorkspaceEnvPrefix(name), logger.With(zap.String("workspace", name))),
+		})
+	}
+	return NewWorkspacesOf(list...)
+}
+
+// NewWorkspacesOf groups already created providers, the first being the
+// default.
+func NewWorkspacesOf(list ...Workspace) *Workspaces {
+	return &Workspaces{transport: list[0].Provider.ServerTransport(), list: list}
+}
+
+// ParseWorkspaceNames splits a comma-separated list of workspace names,
+// rejecting empty, malformed and duplicate ones. Names are compared without
+// regard to case, as they end up in environment variable names.
+func ParseWorkspaceNames(raw string) ([]string, error) {
+	var names []string
+	seen := make(map[string]bool)
+	for _, name := range strings.Split(raw, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if !workspaceNameRe.MatchString(name) {
+			return nil, fmt.Errorf("invalid workspace name %q: use letters, digits, '-' and '_'", name)
+		}
+		key := strings.ToLower(name)
+		if seen[key] {
+			return nil, fmt.Errorf("duplicate workspace name %q", name)
+		}
+		seen[key] = true
+		names = append(names, name)
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("no workspace names in %q", raw)
+	}
+	return names, nil
+}
+
+// WorkspaceEnvPrefix returns the prefix of the environment variables holding
+// the tokens of the named workspace, e.g. SLACK_MCP_ACME_CORP_ for acme-corp.
+func WorkspaceEnvPrefix(name string) string {
+	return "SLACK_MCP_" + strings.ToUpper(strings.ReplaceAll(name, "-", "_")) + "_"
+}
+
+// All returns the workspaces in configuration order.
+func (w *Workspaces) All() []Workspace {
+	return w.list
+}
+
+// Default returns the provider of the first configured workspace, nil with
+// per-session credentials.
+func (w *Workspaces) Default() *ApiProvider {
+	if len(w.list) == 0 {
+		return nil
+	}
+	return w.list[0].Provider
+}
+
+// PerSession reports whether every HTTP session supplies its own credentials.
+func (w *Workspaces) PerSession() bool {
+	return w.perSession
+}
+
+// Transport returns the transport the providers are created for.
+func (w *Workspaces) Transport() string {
+	return w.transport
+}
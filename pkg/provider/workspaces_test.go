@@ -0,0 +1,62 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestUnitParseWorkspaceNames(t *testing.T) {
+	names, err := ParseWorkspaceNames(" acme, globex-corp ,,")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"acme", "globex-corp"}, names)
+
+	_, err = ParseWorkspaceNames("acme,ACME")
+	assert.ErrorContains(t, err, "duplicate")
+
+	_, err = ParseWorkspaceNames("acme,globex corp")
+	assert.ErrorContains(t, err, "invalid workspace name")
+
+	_, err = ParseWorkspaceNames(" , ")
+	assert.Error(t, err)
+}
+
+func TestUnitWorkspaceEnvPrefix(t *testing.T) {
+	assert.Equal(t, "SLACK_MCP_ACME_", WorkspaceEnvPrefix("acme"))
+	assert.Equal(t, "SLACK_MCP_GLOBEX_CORP_", WorkspaceEnvPrefix("globex-corp"))
+}
+
+func TestUnitNewWorkspaces(t *testing.T) {
+	t.Setenv("SLACK_MCP_XOXP_TOKEN", "demo")
+
+	single := NewWorkspaces("stdio", zap.NewNop())
+	require.Len(t, single.All(), 1)
+	assert.Empty(t, single.All()[0].Name)
+	assert.Same(t, single.All()[0].Provider, single.Default())
+
+	t.Setenv("SLACK_MCP_WORKSPACES", "acme,globex")
+	t.Setenv("SLACK_MCP_ACME_XOXP_TOKEN", "demo")
+	t.Setenv("SLACK_MCP_GLOBEX_XOXC_TOKEN", "demo")
+	t.Setenv("SLACK_MCP_GLOBEX_XOXD_TOKEN", "demo")
+
+	named := NewWorkspaces("stdio", zap.NewNop())
+	require.Len(t, named.All(), 2)
+	assert.Equal(t, "acme", named.All()[0].Name)
+	assert.Equal(t, "globex", named.All()[1].Name)
+	assert.Same(t, named.All()[0].Provider, named.Default())
+	assert.NotSame(t, named.All()[0].Provider, named.All()[1].Provider)
+}
+
+func TestUnitNewFromEnvDemo(t *testing.T) {
+	t.Setenv("SLACK_MCP_XOXP_TOKEN", "")
+	t.Setenv("SLACK_MCP_ACME_XOXP_TOKEN", "demo")
+
+	p := newFromEnv("stdio", "SLACK_MCP_ACME_", zap.NewNop())
+	assert.True(t, p.IsDemo())
+
+	ar, err := p.Slack().AuthTest()
+	require.NoError(t, err)
+	assert.Equal(t, "Demo Team", ar.Team)
+}
@@ -0,0 +1,56 @@
+package realtime
+
+import "sync"
+
+// Buffer is a fixed-size ring of the most recent events.
+type Buffer struct {
+	mu     sync.RWMutex
+	events []Event
+	next   int
+	full   bool
+}
+
+func NewBuffer(size int) *Buffer {
+	if size <= 0 {
+		size = defaultBufferSize
+	}
+	return &Buffer{
+		events: make([]Event, size),
+	}
+}
+
+func (b *Buffer) Add(e Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.events[b.next] = e
+	b.next = (b.next + 1) % len(b.events)
+	if b.next == 0 {
+		b.full = true
+	}
+}
+
+// Recent returns up to limit events matching filter, newest first. A nil
+// filter matches everything, a non-positive limit returns all matches.
+func (b *Buffer) Recent(filter func(Event) bool, limit int) []Event {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	n := b.next
+	if b.full {
+		n = len(b.events)
+	}
+
+	var res []Event
+	for i := 0; i < n; i++ {
+		e := b.events[(b.next-1-i+len(b.events))%len(b.events)]
+		if filter != nil && !filter(e) {
+			continue
+		}
+		res = append(res, e)
+		if limit > 0 && len(res) >= limit {
+			break
+		}
+	}
+	return res
+}
@@ -0,0 +1,141 @@
+package realtime
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+// Event is a normalized Slack event as kept in the recent-events buffer.
+type Event struct {
+	Type       string
+	Subtype    string
+	ChannelID  string
+	UserID     string
+	Ts         string
+	ThreadTs   string
+	Text       string
+	Reaction   string
+	ReceivedAt time.Time
+}
+
+// handledEventTypes lists the events the ingestor understands, everything
+// else is dropped before decoding.
+var handledEventTypes = map[string]bool{
+	"message":               true,
+	"reaction_added":        true,
+	"reaction_removed":      true,
+	"channel_created":       true,
+	"channel_rename":        true,
+	"channel_deleted":       true,
+	"member_joined_channel": true,
+	"member_left_channel":   true,
+	"user_change":           true,
+	"team_join":             true,
+}
+
+// rawEvent covers the fields of all handled event types. Socket Mode and RTM
+// deliver the same payloads, except that "channel" and "user" are objects
+// for channel_* and user events and plain IDs for the rest.
+type rawEvent struct {
+	Type      string          `json:"type"`
+	Subtype   string          `json:"subtype"`
+	Channel   json.RawMessage `json:"channel"`
+	User      json.RawMessage `json:"user"`
+	Text      string          `json:"text"`
+	Ts        string          `json:"ts"`
+	ThreadTs  string          `json:"thread_ts"`
+	DeletedTs string          `json:"deleted_ts"`
+	Topic     string          `json:"topic"`
+	Purpose   string          `json:"purpose"`
+	Reaction  string          `json:"reaction"`
+	Item      struct {
+		Channel string `json:"channel"`
+		Ts      string `json:"ts"`
+	} `json:"item"`
+	Message *struct {
+		User     string `json:"user"`
+		Text     string `json:"text"`
+		Ts       string `json:"ts"`
+		ThreadTs string `json:"thread_ts"`
+	} `json:"message"`
+}
+
+type channelInfo struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// decodeEvent turns a raw Slack event into an Event, returning the channel
+// or user object carried by channel_* and user events alongside.
+func decodeEvent(raw json.RawMessage, now time.Time) (Event, *channelInfo, *slack.User, error) {
+	var r rawEvent
+	if err := json.Unmarshal(raw, &r); err != nil {
+		return Event{}, nil, nil, err
+	}
+
+	e := Event{
+		Type:       r.Type,
+		Subtype:    r.Subtype,
+		Text:       r.Text,
+		Ts:         r.Ts,
+		ThreadTs:   r.ThreadTs,
+		Reaction:   r.Reaction,
+		ReceivedAt: now,
+	}
+
+	var (
+		channel *channelInfo
+		user    *slack.User
+	)
+	if id, ok := jsonString(r.Channel); ok {
+		e.ChannelID = id
+	} else if len(r.Channel) > 0 {
+		channel = &channelInfo{}
+		if err := json.Unmarshal(r.Channel, channel); err != nil {
+			return Event{}, nil, nil, err
+		}
+		e.ChannelID = channel.ID
+	}
+	if id, ok := jsonString(r.User); ok {
+		e.UserID = id
+	} else if len(r.User) > 0 {
+		user = &slack.User{}
+		if err := json.Unmarshal(r.User, user); err != nil {
+			return Event{}, nil, nil, err
+		}
+		e.UserID = user.ID
+	}
+
+	switch {
+	case r.Type == "reaction_added" || r.Type == "reaction_removed":
+		e.ChannelID = r.Item.Channel
+		e.Ts = r.Item.Ts
+	case r.Subtype == "message_changed" && r.Message != nil:
+		e.UserID = r.Message.User
+		e.Text = r.Message.Text
+		e.Ts = r.Message.Ts
+		e.ThreadTs = r.Message.ThreadTs
+	case r.Subtype == "message_deleted":
+		e.Ts = r.DeletedTs
+	case r.Subtype == "channel_topic":
+		e.Text = r.Topic
+	case r.Subtype == "channel_purpose":
+		e.Text = r.Purpose
+	}
+
+	return e, channel, user, nil
+}
+
+// jsonString reports whether raw holds a JSON string and returns it.
+func jsonString(raw json.RawMessage) (string, bool) {
+	if len(raw) == 0 || raw[0] != '"' {
+		return "", false
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return "", false
+	}
+	return s, true
+}
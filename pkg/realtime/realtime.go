@@ -0,0 +1,276 @@
+// Package realtime ingests Slack events over Socket Mode or RTM websockets,
+// keeps the provider caches current and buffers recent events for tools.
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/slack-go/slack"
+	"github.com/slack-go/slack/socketmode"
+	"go.uber.org/zap"
+)
+
+const (
+	ModeRTM        = "rtm"
+	ModeSocketMode = "socketmode"
+
+	defaultBufferSize = 1000
+)
+
+var ErrInvalidAuth = errors.New("realtime: invalid authentication, check the token and its scopes")
+
+type Ingestor struct {
+	provider *provider.ApiProvider
+	logger   *zap.Logger
+	buffer   *Buffer
+	notify   map[string]bool
+
+	mu        sync.RWMutex
+	listeners []func(Event)
+}
+
+// New creates an ingestor configured from SLACK_MCP_REALTIME_BUFFER and
+// SLACK_MCP_REALTIME_NOTIFY.
+func New(p *provider.ApiProvider, logger *zap.Logger) *Ingestor {
+	size := defaultBufferSize
+	if raw := os.Getenv("SLACK_MCP_REALTIME_BUFFER"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n <= 0 {
+			logger.Warn("Invalid SLACK_MCP_REALTIME_BUFFER, using default",
+				zap.String("value", raw),
+				zap.Int("default", defaultBufferSize),
+			)
+		} else {
+			size = n
+		}
+	}
+
+	// Nothing is notified unless asked for: events of every channel would
+	// otherwise reach every subscriber of its history.
+	notify := make(map[string]bool)
+	for _, t := range strings.Split(os.Getenv("SLACK_MCP_REALTIME_NOTIFY"), ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			notify[t] = true
+		}
+	}
+
+	return &Ingestor{
+		provider: p,
+		logger:   logger,
+		buffer:   NewBuffer(size),
+		notify:   notify,
+	}
+}
+
+// Buffer returns the recent-events buffer.
+func (in *Ingestor) Buffer() *Buffer {
+	return in.buffer
+}
+
+// OnEvent registers fn to be called for every event whose type is selected
+// by SLACK_MCP_REALTIME_NOTIFY.
+func (in *Ingestor) OnEvent(fn func(Event)) {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+
+	in.listeners = append(in.listeners, fn)
+}
+
+// Run connects using the given mode and blocks until ctx is done or the
+// connection fails for good.
+func (in *Ingestor) Run(ctx context.Context, mode string) error {
+	switch mode {
+	case ModeRTM:
+		api := in.provider.SlackClient()
+		if api == nil {
+			return errors.New("realtime: RTM requires a Slack client, demo credentials are not supported")
+		}
+		return in.RunRTM(ctx, api)
+	case ModeSocketMode:
+		appToken := os.Getenv("SLACK_MCP_APP_TOKEN")
+		if !strings.HasPrefix(appToken, "xapp-") {
+			return errors.New("realtime: socket mode requires an app-level token (xapp-...) in SLACK_MCP_APP_TOKEN")
+		}
+		return in.RunSocketMode(ctx, slack.New("", slack.OptionAppLevelToken(appToken)))
+	default:
+		return fmt.Errorf("realtime: unknown mode %q, allowed values: %s, %s", mode, ModeRTM, ModeSocketMode)
+	}
+}
+
+// RunRTM ingests events from the RTM websocket. It works with user tokens,
+// including browser session tokens.
+func (in *Ingestor) RunRTM(ctx context.Context, api *slack.Client) error {
+	rtm := api.NewRTM()
+	go rtm.ManageConnection()
+	defer func() {
+		_ = rtm.Disconnect()
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case evt, ok := <-rtm.IncomingEvents:
+			if !ok {
+				return nil
+			}
+			switch data := evt.Data.(type) {
+			case *slack.ConnectedEvent:
+				in.logger.Info("Connected to Slack RTM", zap.Int("connection_count", data.ConnectionCount))
+			case *slack.ConnectionErrorEvent:
+				in.logger.Warn("Slack RTM connection error", zap.Error(data), zap.Duration("backoff", data.Backoff))
+			case *slack.InvalidAuthEvent:
+				return ErrInvalidAuth
+			default:
+				if !handledEventTypes[evt.Type] {
+					continue
+				}
+				raw, err := json.Marshal(data)
+				if err != nil {
+					in.logger.Warn("Failed to encode RTM event", zap.String("type", evt.Type), zap.Error(err))
+					continue
+				}
+				in.ingest(raw)
+			}
+		}
+	}
+}
+
+// RunSocketMode ingests Events API payloads delivered over Socket Mode. The
+// client must carry an app-level token.
+func (in *Ingestor) RunSocketMode(ctx context.Context, api *slack.Client) error {
+	client := socketmode.New(api)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	errc := make(chan error, 1)
+	go func() {
+		errc <- client.RunContext(ctx)
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case err := <-errc:
+			return err
+		case evt := <-client.Events:
+			switch evt.Type {
+			case socketmode.EventTypeConnected:
+				in.logger.Info("Connected to Slack Socket Mode")
+			case socketmode.EventTypeConnectionError:
+				in.logger.Warn("Slack Socket Mode connection error", zap.Any("data", evt.Data))
+			case socketmode.EventTypeInvalidAuth:
+				return ErrInvalidAuth
+			case socketmode.EventTypeEventsAPI:
+				if evt.Request == nil {
+					continue
+				}
+				client.Ack(*evt.Request)
+
+				var payload struct {
+					Event json.RawMessage `json:"event"`
+				}
+				if err := json.Unmarshal(evt.Request.Payload, &payload); err != nil {
+					in.logger.Warn("Failed to decode Socket Mode payload", zap.Error(err))
+					continue
+				}
+				in.ingest(payload.Event)
+			}
+		}
+	}
+}
+
+// ingest decodes a single event, applies it to the provider caches, stores
+// it in the buffer and forwards it to the listeners when selected.
+func (in *Ingestor) ingest(raw json.RawMessage) {
+	var head struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(raw, &head); err != nil || !handledEventTypes[head.Type] {
+		return
+	}
+
+	e, channel, user, err := decodeEvent(raw, time.Now())
+	if err != nil {
+		in.logger.Warn("Failed to decode Slack event", zap.String("type", head.Type), zap.Error(err))
+		return
+	}
+	in.logger.Debug("Received Slack event",
+		zap.String("type", e.Type),
+		zap.String("subtype", e.Subtype),
+		zap.String("channel", e.ChannelID),
+	)
+
+	in.applyToCaches(e, channel, user)
+	in.buffer.Add(e)
+
+	if !in.notify[e.Type] {
+		return
+	}
+	in.mu.RLock()
+	listeners := in.listeners
+	in.mu.RUnlock()
+	for _, fn := range listeners {
+		fn(e)
+	}
+}
+
+func (in *Ingestor) applyToCaches(e Event, channel *channelInfo, user *slack.User) {
+	if in.provider == nil {
+		return
+	}
+
+	switch e.Type {
+	case "channel_created", "channel_rename":
+		if channel == nil || channel.ID == "" {
+			return
+		}
+		c, _ := in.provider.Channels().Get(channel.ID)
+		c.ID = channel.ID
+		c.Name = "#" + channel.Name
+		in.provider.UpsertChannel(c)
+	case "channel_deleted":
+		in.provider.RemoveChannel(e.ChannelID)
+	case "member_joined_channel", "member_left_channel":
+		c, ok := in.provider.Channels().Get(e.ChannelID)
+		if !ok {
+			return
+		}
+		if e.Type == "member_joined_channel" {
+			c.MemberCount++
+		} else if c.MemberCount > 0 {
+			c.MemberCount--
+		}
+		in.provider.UpsertChannel(c)
+	case "message":
+		if e.Subtype != "channel_topic" && e.Subtype != "channel_purpose" {
+			return
+		}
+		c, ok := in.provider.Channels().Get(e.ChannelID)
+		if !ok {
+			return
+		}
+		if e.Subtype == "channel_topic" {
+			c.Topic = e.Text
+		} else {
+			c.Purpose = e.Text
+		}
+		in.provider.UpsertChannel(c)
+	case "user_change", "team_join":
+		if user == nil || user.ID == "" {
+			return
+		}
+		in.provider.UpsertUser(*user)
+	}
+}
@@ -0,0 +1,202 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// newSlackStandIn serves rtm.connect and apps.connections.open, both pointing
+// to a websocket endpoint that writes the given frames once connected.
+func newSlackStandIn(t *testing.T, frames []string) *httptest.Server {
+	t.Helper()
+
+	// RTM dials with Origin https://api.slack.com.
+	upgrader := websocket.Upgrader{CheckOrigin: func(*http.Request) bool { return true }}
+	mux := http.NewServeMux()
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	wsURL := "ws" + strings.TrimPrefix(srv.URL, "http") + "/ws"
+
+	mux.HandleFunc("/rtm.connect", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"ok":true,"url":%q,"self":{"id":"U0","name":"me"},"team":{"id":"T1","name":"Team","domain":"team"}}`, wsURL)
+	})
+	mux.HandleFunc("/apps.connections.open", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"ok":true,"url":%q}`, wsURL)
+	})
+	mux.HandleFunc("/ws", func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		for _, f := range frames {
+			if err := conn.WriteMessage(websocket.TextMessage, []byte(f)); err != nil {
+				return
+			}
+		}
+		// Keep reading acks and pings until the client goes away.
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	})
+
+	return srv
+}
+
+func newTestProvider(t *testing.T) *provider.ApiProvider {
+	t.Helper()
+	t.Setenv("SLACK_MCP_XOXP_TOKEN", "demo")
+	return provider.New("stdio", zap.NewNop())
+}
+
+func envelope(t *testing.T, id, event string) string {
+	t.Helper()
+	b, err := json.Marshal(map[string]any{
+		"envelope_id":              id,
+		"type":                     "events_api",
+		"accepts_response_payload": false,
+		"payload": map[string]any{
+			"type":       "event_callback",
+			"team_id":    "T1",
+			"api_app_id": "A1",
+			"event_id":   "Ev" + id,
+			"event_time": 1700000000,
+			"event":      json.RawMessage(event),
+		},
+	})
+	require.NoError(t, err)
+	return string(b)
+}
+
+func TestUnitRunRTM(t *testing.T) {
+	srv := newSlackStandIn(t, []string{
+		`{"type":"hello"}`,
+		`{"type":"channel_created","channel":{"id":"C9","name":"launch","created":1700000000,"creator":"U1"}}`,
+		`{"type":"message","channel":"C9","user":"U1","text":"we are live","ts":"1700000001.000100"}`,
+		`{"type":"reaction_added","user":"U2","reaction":"tada","item":{"type":"message","channel":"C9","ts":"1700000001.000100"}}`,
+	})
+
+	p := newTestProvider(t)
+	t.Setenv("SLACK_MCP_REALTIME_NOTIFY", "message, reaction_added")
+	in := New(p, zap.NewNop())
+
+	var notified []Event
+	notifiedCh := make(chan Event, 10)
+	in.OnEvent(func(e Event) { notifiedCh <- e })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan error, 1)
+	go func() {
+		done <- in.RunRTM(ctx, slack.New("xoxp-test", slack.OptionAPIURL(srv.URL+"/")))
+	}()
+
+	for len(notified) < 2 {
+		select {
+		case e := <-notifiedCh:
+			notified = append(notified, e)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for events, got %d", len(notified))
+		}
+	}
+	cancel()
+	<-done
+
+	assert.Equal(t, "message", notified[0].Type)
+	assert.Equal(t, "C9", notified[0].ChannelID)
+	assert.Equal(t, "we are live", notified[0].Text)
+	assert.Equal(t, "reaction_added", notified[1].Type)
+	assert.Equal(t, "tada", notified[1].Reaction)
+	assert.Equal(t, "1700000001.000100", notified[1].Ts)
+
+	recent := in.Buffer().Recent(nil, 0)
+	require.Len(t, recent, 3)
+	assert.Equal(t, "reaction_added", recent[0].Type)
+	assert.Equal(t, "channel_created", recent[2].Type)
+
+	c, _ := p.Channels().Get("C9")
+	assert.Equal(t, "#launch", c.Name)
+	c, _ = p.Channels().ByName("#launch")
+	assert.Equal(t, "C9", c.ID)
+}
+
+func TestUnitRunSocketMode(t *testing.T) {
+	srv := newSlackStandIn(t, []string{
+		`{"type":"hello","num_connections":1,"connection_info":{"app_id":"A1"}}`,
+		envelope(t, "1", `{"type":"user_change","user":{"id":"U7","name":"jane","real_name":"Jane Doe"}}`),
+		envelope(t, "2", `{"type":"message","subtype":"message_changed","channel":"C1","message":{"user":"U7","text":"edited","ts":"1700000000.000100"}}`),
+	})
+
+	p := newTestProvider(t)
+	t.Setenv("SLACK_MCP_REALTIME_NOTIFY", "message")
+	in := New(p, zap.NewNop())
+
+	notifiedCh := make(chan Event, 10)
+	in.OnEvent(func(e Event) { notifiedCh <- e })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan error, 1)
+	go func() {
+		done <- in.RunSocketMode(ctx, slack.New("", slack.OptionAppLevelToken("xapp-test"), slack.OptionAPIURL(srv.URL+"/")))
+	}()
+
+	var e Event
+	select {
+	case e = <-notifiedCh:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for message event")
+	}
+	cancel()
+	<-done
+
+	assert.Equal(t, "message_changed", e.Subtype)
+	assert.Equal(t, "U7", e.UserID)
+	assert.Equal(t, "edited", e.Text)
+	assert.Equal(t, "1700000000.000100", e.Ts)
+
+	u, _ := p.Users().Get("U7")
+	assert.Equal(t, "Jane Doe", u.RealName)
+	u, _ = p.Users().ByName("jane")
+	assert.Equal(t, "U7", u.ID)
+}
+
+func TestUnitNotifyTypes(t *testing.T) {
+	p := newTestProvider(t)
+	assert.Empty(t, New(p, zap.NewNop()).notify)
+
+	t.Setenv("SLACK_MCP_REALTIME_NOTIFY", "message, reaction_added,")
+	assert.Equal(t, map[string]bool{"message": true, "reaction_added": true}, New(p, zap.NewNop()).notify)
+}
+
+func TestUnitBufferRecent(t *testing.T) {
+	b := NewBuffer(3)
+	for i := 1; i <= 5; i++ {
+		b.Add(Event{Ts: fmt.Sprintf("%d.0", i), ChannelID: map[bool]string{true: "C1", false: "C2"}[i%2 == 1]})
+	}
+
+	all := b.Recent(nil, 0)
+	require.Len(t, all, 3)
+	assert.Equal(t, []string{"5.0", "4.0", "3.0"}, []string{all[0].Ts, all[1].Ts, all[2].Ts})
+
+	c1 := b.Recent(func(e Event) bool { return e.ChannelID == "C1" }, 1)
+	require.Len(t, c1, 1)
+	assert.Equal(t, "5.0", c1[0].Ts)
+}
@@ -0,0 +1,90 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"go.uber.org/zap"
+)
+
+const (
+	// HeaderSlackToken carries the xoxp or xoxc token of a session.
+	HeaderSlackToken = "X-Slack-Token"
+	// HeaderSlackCookie carries the xoxd cookie that goes with an xoxc token.
+	HeaderSlackCookie = "X-Slack-Cookie"
+)
+
+// KeyRing maps API keys to the Slack credentials of their owner.
+type KeyRing map[string]provider.Credentials
+
+// LoadKeyRing reads a JSON object of API keys to credentials, e.g.
+// {"key-1": {"token": "xoxp-..."}, "key-2": {"token": "xoxc-...", "cookie": "xoxd-..."}}.
+func LoadKeyRing(path string) (KeyRing, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var keys KeyRing
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	for key, creds := range keys {
+		if key == "" || creds.Token == "" {
+			return nil, fmt.Errorf("failed to parse %s: every entry needs a key and a token", path)
+		}
+	}
+	return keys, nil
+}
+
+// credentialsKey is a custom context key for storing session credentials.
+type credentialsKey struct{}
+
+type sessionCredentials struct {
+	creds provider.Credentials
+	// fromKeyRing tells that the API key of the request was found in the
+	// key ring, which authenticates it.
+	fromKeyRing bool
+}
+
+// CredentialsFromRequest extracts the Slack credentials of a request: the
+// ones an API key in the Authorization header maps to, or else the
+// X-Slack-Token and X-Slack-Cookie headers.
+func CredentialsFromRequest(keys KeyRing, logger *zap.Logger) func(context.Context, *http.Request) context.Context {
+	return func(ctx context.Context, r *http.Request) context.Context {
+		apiKey := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if creds, ok := keys[apiKey]; ok && apiKey != "" {
+			return context.WithValue(ctx, credentialsKey{}, sessionCredentials{creds: creds, fromKeyRing: true})
+		}
+
+		token := r.Header.Get(HeaderSlackToken)
+		if token == "" {
+			logger.Debug("No Slack credentials in request",
+				zap.String("context", "http"),
+			)
+			return ctx
+		}
+		return context.WithValue(ctx, credentialsKey{}, sessionCredentials{
+			creds: provider.Credentials{
+				Token:  token,
+				Cookie: r.Header.Get(HeaderSlackCookie),
+			},
+		})
+	}
+}
+
+// CredentialsFromContext returns the Slack credentials the request carried.
+func CredentialsFromContext(ctx context.Context) (provider.Credentials, bool) {
+	sc, ok := ctx.Value(credentialsKey{}).(sessionCredentials)
+	return sc.creds, ok
+}
+
+func authenticatedByKeyRing(ctx context.Context) bool {
+	sc, ok := ctx.Value(credentialsKey{}).(sessionCredentials)
+	return ok && sc.fromKeyRing
+}
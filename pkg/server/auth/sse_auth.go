@@ -23,6 +23,14 @@ func withAuthKey(ctx context.Context, auth string) context.Context {
 
 // Authenticate checks if the request is authenticated based on the provided context.
 func validateToken(ctx context.Context, logger *zap.Logger) (bool, error) {
+	// an API key from the key ring is valid on its own
+	if authenticatedByKeyRing(ctx) {
+		logger.Debug("Auth token found in key ring",
+			zap.String("context", "http"),
+		)
+		return true, nil
+	}
+
 	// no configured token means no authentication
 	keyA := os.Getenv("SLACK_MCP_API_KEY")
 	if keyA == "" {
@@ -0,0 +1,36 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"go.uber.org/zap"
+)
+
+const methodCompletionComplete = "completion/complete"
+
+// complete answers completion/complete with the completion handler of the
+// workspace the request refers to.
+func (c *interceptor) complete(ctx context.Context, id mcp.RequestId, raw []byte) mcp.JSONRPCMessage {
+	var complete mcp.CompleteRequest
+	if err := json.Unmarshal(raw, &complete); err != nil {
+		return mcp.NewJSONRPCError(id, mcp.INVALID_PARAMS, err.Error(), nil)
+	}
+
+	h, err := c.router.completionsFor(ctx, complete.Params.Ref)
+	if err != nil {
+		return mcp.NewJSONRPCError(id, mcp.INVALID_REQUEST, err.Error(), nil)
+	}
+
+	result, err := h.Complete(ctx, complete)
+	if err != nil {
+		c.logger.Error("Completion failed", zap.Error(err))
+		return mcp.NewJSONRPCError(id, mcp.INTERNAL_ERROR, err.Error(), nil)
+	}
+	return mcp.JSONRPCResponse{
+		JSONRPC: mcp.JSONRPC_VERSION,
+		ID:      id,
+		Result:  result,
+	}
+}
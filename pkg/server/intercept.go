@@ -0,0 +1,273 @@
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/server/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"go.uber.org/zap"
+)
+
+// interceptor answers the requests mcp-go does not dispatch:
+// completion/complete, resources/subscribe and resources/unsubscribe. They
+// are taken out of the message stream in front of each transport and
+// answered here, and the completions capability, which mcp-go does not
+// advertise either, is added to the initialize results on their way out.
+type interceptor struct {
+	router        *workspaceRouter
+	subscriptions *subscriptions
+	transport     string
+	logger        *zap.Logger
+}
+
+// handle answers raw when it is one of the intercepted requests and reports
+// whether it did.
+func (c *interceptor) handle(ctx context.Context, raw []byte) (mcp.JSONRPCMessage, bool) {
+	if !bytes.Contains(raw, []byte(methodCompletionComplete)) && !bytes.Contains(raw, []byte(methodResourcesSubscribe)) && !bytes.Contains(raw, []byte(methodResourcesUnsubscribe)) {
+		return nil, false
+	}
+
+	var request struct {
+		ID     mcp.RequestId `json:"id"`
+		Method string        `json:"method"`
+	}
+	if err := json.Unmarshal(raw, &request); err != nil || request.ID.IsNil() {
+		return nil, false
+	}
+
+	var answer func(context.Context, mcp.RequestId, []byte) mcp.JSONRPCMessage
+	switch request.Method {
+	case methodCompletionComplete:
+		answer = c.complete
+	case methodResourcesSubscribe:
+		answer = c.subscribe
+	case methodResourcesUnsubscribe:
+		answer = c.unsubscribe
+	default:
+		return nil, false
+	}
+
+	if authenticated, err := auth.IsAuthenticated(ctx, c.transport, c.logger); !authenticated {
+		c.logger.Error("Authentication failed", zap.String("method", request.Method), zap.Error(err))
+		return mcp.NewJSONRPCError(request.ID, mcp.INVALID_REQUEST, err.Error(), nil), true
+	}
+
+	return answer(ctx, request.ID, raw), true
+}
+
+// serveStdio runs the stdio transport, answering the intercepted requests
+// from stdin before the rest reaches mcp-go.
+func (c *interceptor) serveStdio(ctx context.Context, s *server.StdioServer, stdin io.Reader, stdout io.Writer) error {
+	out := &lockedWriter{w: stdout}
+	pr, pw := io.Pipe()
+	ctx = withSessionID(ctx, stdioSessionID)
+
+	go func() {
+		reader := bufio.NewReader(stdin)
+		for {
+			line, err := reader.ReadBytes('\n')
+			if len(line) > 0 {
+				if response, ok := c.handle(ctx, line); ok {
+					if err := out.writeMessage(response); err != nil {
+						c.logger.Error("Failed to write response", zap.Error(err))
+					}
+				} else if _, err := pw.Write(line); err != nil {
+					return
+				}
+			}
+			if err != nil {
+				_ = pw.CloseWithError(err)
+				return
+			}
+		}
+	}()
+
+	return s.Listen(ctx, pr, capabilityWriter{out})
+}
+
+// httpMiddleware answers the intercepted requests posted to the message
+// endpoint of the HTTP transports. respond delivers the response, which the
+// SSE transport sends over the event stream instead of the HTTP response.
+func (c *interceptor) httpMiddleware(next http.Handler, path string, contextFunc func(context.Context, *http.Request) context.Context, respond func(http.ResponseWriter, *http.Request, mcp.JSONRPCMessage)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w = capabilityResponseWriter{w}
+		if r.Method == http.MethodDelete && r.URL.Path == path {
+			// The streamable HTTP client ends its session.
+			c.subscriptions.drop(requestSessionID(r))
+		}
+		if r.Method != http.MethodPost || r.URL.Path != path {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, "Failed to read request body", http.StatusBadRequest)
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		ctx := withSessionID(contextFunc(r.Context(), r), requestSessionID(r))
+		response, ok := c.handle(ctx, body)
+		if !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+		respond(w, r, response)
+	})
+}
+
+// stdioSessionID is the ID mcp-go gives the single session of the stdio
+// transport.
+const stdioSessionID = "stdio"
+
+// sessionIDKey is a custom context key for storing the MCP session ID of
+// an intercepted request.
+type sessionIDKey struct{}
+
+func withSessionID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, sessionIDKey{}, id)
+}
+
+func sessionIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(sessionIDKey{}).(string)
+	return id
+}
+
+// requestSessionID returns the MCP session ID an HTTP request belongs to:
+// the Mcp-Session-Id header of the streamable HTTP transport, or the
+// sessionId query parameter of the SSE transport.
+func requestSessionID(r *http.Request) string {
+	if id := r.Header.Get(server.HeaderKeySessionID); id != "" {
+		return id
+	}
+	return r.URL.Query().Get("sessionId")
+}
+
+// advertiseCompletions adds the completions capability to the initialize
+// result in p, a JSON-RPC message that may be framed as a server-sent event,
+// and returns any other message unchanged.
+func advertiseCompletions(p []byte) []byte {
+	if !bytes.Contains(p, []byte(`"protocolVersion"`)) || !bytes.Contains(p, []byte(`"capabilities"`)) {
+		return p
+	}
+	start, end := bytes.IndexByte(p, '{'), bytes.LastIndexByte(p, '}')
+	if start < 0 || end < start {
+		return p
+	}
+
+	var message map[string]json.RawMessage
+	if err := json.Unmarshal(p[start:end+1], &message); err != nil {
+		return p
+	}
+	var result map[string]json.RawMessage
+	if err := json.Unmarshal(message["result"], &result); err != nil || result["protocolVersion"] == nil || result["serverInfo"] == nil {
+		return p
+	}
+	var capabilities map[string]json.RawMessage
+	if err := json.Unmarshal(result["capabilities"], &capabilities); err != nil {
+		return p
+	}
+	if capabilities == nil {
+		capabilities = map[string]json.RawMessage{}
+	}
+	capabilities["completions"] = json.RawMessage(`{}`)
+
+	var err error
+	if result["capabilities"], err = json.Marshal(capabilities); err != nil {
+		return p
+	}
+	if message["result"], err = json.Marshal(result); err != nil {
+		return p
+	}
+	body, err := json.Marshal(message)
+	if err != nil {
+		return p
+	}
+
+	out := make([]byte, 0, len(p)+len(`,"completions":{}`))
+	out = append(out, p[:start]...)
+	out = append(out, body...)
+	return append(out, p[end+1:]...)
+}
+
+// capabilityWriter passes the messages of mcp-go through
+// advertiseCompletions. mcp-go writes every message with a single Write.
+type capabilityWriter struct {
+	io.Writer
+}
+
+func (cw capabilityWriter) Write(p []byte) (int, error) {
+	if _, err := cw.Writer.Write(advertiseCompletions(p)); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// capabilityResponseWriter is capabilityWriter for the HTTP transports,
+// which flush every server-sent event.
+type capabilityResponseWriter struct {
+	http.ResponseWriter
+}
+
+func (cw capabilityResponseWriter) Write(p []byte) (int, error) {
+	if _, err := cw.ResponseWriter.Write(advertiseCompletions(p)); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func (cw capabilityResponseWriter) Flush() {
+	if f, ok := cw.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (cw capabilityResponseWriter) Unwrap() http.ResponseWriter {
+	return cw.ResponseWriter
+}
+
+func writeJSONResponse(w http.ResponseWriter, _ *http.Request, response mcp.JSONRPCMessage) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+func sseResponder(sse *server.SSEServer) func(http.ResponseWriter, *http.Request, mcp.JSONRPCMessage) {
+	return func(w http.ResponseWriter, r *http.Request, response mcp.JSONRPCMessage) {
+		if err := sse.SendEventToSession(r.URL.Query().Get("sessionId"), response); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}
+}
+
+// lockedWriter serializes whole messages written by mcp-go and by the
+// interceptor to the same stream.
+type lockedWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (lw *lockedWriter) Write(p []byte) (int, error) {
+	lw.mu.Lock()
+	defer lw.mu.Unlock()
+
+	return lw.w.Write(p)
+}
+
+func (lw *lockedWriter) writeMessage(message mcp.JSONRPCMessage) error {
+	b, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+	_, err = lw.Write(append(b, '\n'))
+	return err
+}
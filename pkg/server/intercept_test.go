@@ -0,0 +1,163 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/handler"
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func newTestInterceptor(t *testing.T, transport string) *interceptor {
+	t.Setenv("SLACK_MCP_XOXP_TOKEN", "demo")
+	p := provider.New(transport, zap.NewNop())
+	p.UpsertChannel(provider.Channel{ID: "C1", Name: "#general", MemberCount: 10})
+
+	router := &workspaceRouter{byName: make(map[string]*workspace)}
+	router.add(&workspace{id: "demo", provider: p, completions: handler.NewCompletionsHandler(p, zap.NewNop())}, "_")
+
+	return &interceptor{
+		router:        router,
+		subscriptions: newSubscriptions(),
+		transport:     transport,
+		logger:        zap.NewNop(),
+	}
+}
+
+type rpcResponse struct {
+	ID     int             `json:"id"`
+	Result json.RawMessage `json:"result"`
+}
+
+func TestUnitCompletionsStdio(t *testing.T) {
+	c := newTestInterceptor(t, "stdio")
+	s := server.NewMCPServer("test", "0.0.0")
+
+	stdinR, stdinW := io.Pipe()
+	stdoutR, stdoutW := io.Pipe()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		_ = c.serveStdio(ctx, server.NewStdioServer(s), stdinR, stdoutW)
+	}()
+
+	go func() {
+		_, _ = io.WriteString(stdinW, `{"jsonrpc":"2.0","id":1,"method":"completion/complete","params":{"ref":{"type":"ref/prompt","name":"channel_summary"},"argument":{"name":"channel","value":"gen"}}}`+"\n")
+		_, _ = io.WriteString(stdinW, `{"jsonrpc":"2.0","id":2,"method":"ping"}`+"\n")
+	}()
+
+	responses := map[int]json.RawMessage{}
+	scanner := bufio.NewScanner(stdoutR)
+	for len(responses) < 2 && scanner.Scan() {
+		var r rpcResponse
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &r))
+		responses[r.ID] = r.Result
+	}
+	require.Len(t, responses, 2)
+
+	assert.JSONEq(t, `{"completion":{"values":["#general"],"total":1}}`, string(responses[1]))
+	assert.JSONEq(t, `{}`, string(responses[2]))
+}
+
+func TestUnitCompletionsHTTPMiddleware(t *testing.T) {
+	c := newTestInterceptor(t, "stdio")
+
+	var passed string
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		passed = string(body)
+	})
+	h := c.httpMiddleware(next, "/mcp", func(ctx context.Context, _ *http.Request) context.Context { return ctx }, writeJSONResponse)
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/mcp", strings.NewReader(
+		`{"jsonrpc":"2.0","id":1,"method":"completion/complete","params":{"ref":{"type":"ref/resource","uri":"slack://demo/channels/{channel_id}/history"},"argument":{"name":"channel_id","value":"#gen"}}}`,
+	)))
+	var r rpcResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &r))
+	assert.JSONEq(t, `{"completion":{"values":["%23general"],"total":1}}`, string(r.Result))
+	assert.Empty(t, passed)
+
+	ping := `{"jsonrpc":"2.0","id":2,"method":"ping"}`
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/mcp", strings.NewReader(ping)))
+	assert.Equal(t, ping, passed)
+}
+
+func TestUnitCompletionsCapabilityAdvertised(t *testing.T) {
+	const initialize = `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-03-26","capabilities":{},"clientInfo":{"name":"test","version":"0.0.0"}}}`
+
+	capabilities := func(t *testing.T, raw []byte) map[string]json.RawMessage {
+		var r struct {
+			Result struct {
+				Capabilities map[string]json.RawMessage `json:"capabilities"`
+			} `json:"result"`
+		}
+		require.NoError(t, json.Unmarshal(raw, &r))
+		return r.Result.Capabilities
+	}
+
+	t.Run("stdio", func(t *testing.T) {
+		c := newTestInterceptor(t, "stdio")
+		s := server.NewMCPServer("test", "0.0.0", server.WithToolCapabilities(true))
+
+		stdinR, stdinW := io.Pipe()
+		stdoutR, stdoutW := io.Pipe()
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		go func() {
+			_ = c.serveStdio(ctx, server.NewStdioServer(s), stdinR, stdoutW)
+		}()
+		go func() {
+			_, _ = io.WriteString(stdinW, initialize+"\n")
+		}()
+
+		scanner := bufio.NewScanner(stdoutR)
+		require.True(t, scanner.Scan())
+		caps := capabilities(t, scanner.Bytes())
+		assert.JSONEq(t, `{}`, string(caps["completions"]))
+		assert.Contains(t, caps, "tools")
+	})
+
+	t.Run("http", func(t *testing.T) {
+		c := newTestInterceptor(t, "stdio")
+		s := server.NewMCPServer("test", "0.0.0", server.WithToolCapabilities(true))
+		ts := httptest.NewServer(c.httpMiddleware(server.NewStreamableHTTPServer(s), "/mcp", func(ctx context.Context, _ *http.Request) context.Context { return ctx }, writeJSONResponse))
+		defer ts.Close()
+
+		resp, err := http.Post(ts.URL+"/mcp", "application/json", strings.NewReader(initialize))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+
+		caps := capabilities(t, body)
+		assert.JSONEq(t, `{}`, string(caps["completions"]))
+		assert.Contains(t, caps, "tools")
+	})
+
+	t.Run("sse event", func(t *testing.T) {
+		event := "event: message\ndata: {\"jsonrpc\":\"2.0\",\"id\":1,\"result\":{\"protocolVersion\":\"2025-03-26\",\"capabilities\":{},\"serverInfo\":{\"name\":\"test\",\"version\":\"0.0.0\"}}}\n\n"
+		out := string(advertiseCompletions([]byte(event)))
+		require.True(t, strings.HasPrefix(out, "event: message\ndata: {"))
+		require.True(t, strings.HasSuffix(out, "}\n\n"))
+		caps := capabilities(t, []byte(strings.TrimSuffix(strings.TrimPrefix(out, "event: message\ndata: "), "\n\n")))
+		assert.JSONEq(t, `{}`, string(caps["completions"]))
+	})
+
+	t.Run("other messages unchanged", func(t *testing.T) {
+		msg := `{"jsonrpc":"2.0","id":2,"result":{"content":[{"type":"text","text":"\"protocolVersion\" \"capabilities\""}]}}`
+		assert.Equal(t, msg, string(advertiseCompletions([]byte(msg))))
+	})
+}
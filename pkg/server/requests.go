@@ -0,0 +1,182 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/progress"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"go.uber.org/zap"
+)
+
+const (
+	methodNotificationCancelled = "notifications/cancelled"
+	methodNotificationProgress  = "notifications/progress"
+)
+
+// requestTracker makes tool calls cancellable with notifications/cancelled
+// and reports their progress when the client sent a progress token.
+//
+// mcp-go neither handles notifications/cancelled nor passes the JSON-RPC id
+// to middlewares, so the id is captured by a BeforeCallTool hook, keyed by
+// the request context which mcp-go hands unchanged to the middleware chain.
+type requestTracker struct {
+	logger *zap.Logger
+
+	mu      sync.Mutex
+	ids     map[context.Context]any
+	cancels map[string]context.CancelFunc
+}
+
+func newRequestTracker(logger *zap.Logger) *requestTracker {
+	return &requestTracker{
+		logger:  logger,
+		ids:     make(map[context.Context]any),
+		cancels: make(map[string]context.CancelFunc),
+	}
+}
+
+func (t *requestTracker) beforeCallTool(ctx context.Context, id any, _ *mcp.CallToolRequest) {
+	if requestID, ok := id.(mcp.RequestId); ok {
+		id = requestID.Value()
+	}
+	if id == nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.ids[ctx] = id
+}
+
+// forget drops the id captured for ctx when the call ended before reaching
+// the middleware, e.g. for an unknown tool.
+func (t *requestTracker) forget(ctx context.Context) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.ids, ctx)
+}
+
+// hooks returns the mcp-go hooks feeding the tracker.
+func (t *requestTracker) hooks() *server.Hooks {
+	hooks := &server.Hooks{}
+	hooks.AddBeforeCallTool(t.beforeCallTool)
+	hooks.AddAfterCallTool(func(ctx context.Context, _ any, _ *mcp.CallToolRequest, _ *mcp.CallToolResult) {
+		t.forget(ctx)
+	})
+	hooks.AddOnError(func(ctx context.Context, _ any, method mcp.MCPMethod, _ any, _ error) {
+		if method == mcp.MethodToolsCall {
+			t.forget(ctx)
+		}
+	})
+	return hooks
+}
+
+func (t *requestTracker) middleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		t.mu.Lock()
+		requestID, tracked := t.ids[ctx]
+		delete(t.ids, ctx)
+		t.mu.Unlock()
+
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		if tracked {
+			key := requestKey(ctx, requestID)
+
+			t.mu.Lock()
+			t.cancels[key] = cancel
+			t.mu.Unlock()
+
+			defer func() {
+				t.mu.Lock()
+				delete(t.cancels, key)
+				t.mu.Unlock()
+			}()
+		}
+
+		if req.Params.Meta != nil && req.Params.Meta.ProgressToken != nil {
+			ctx = progress.WithReporter(ctx, t.progressReporter(ctx, req.Params.Meta.ProgressToken))
+		}
+
+		return next(ctx, req)
+	}
+}
+
+// handleCancelled stops the tool call named by a notifications/cancelled
+// sent by the same session.
+func (t *requestTracker) handleCancelled(ctx context.Context, notification mcp.JSONRPCNotification) {
+	requestID, ok := notification.Params.AdditionalFields["requestId"]
+	if !ok {
+		return
+	}
+	key := requestKey(ctx, requestID)
+
+	t.mu.Lock()
+	cancel, ok := t.cancels[key]
+	t.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	t.logger.Info("Tool call cancelled by client",
+		zap.Any("request_id", requestID),
+		zap.Any("reason", notification.Params.AdditionalFields["reason"]),
+	)
+	cancel()
+}
+
+// progressReporter sends notifications/progress for token. Progress must
+// increase, so values not above the last one sent are dropped; this lets
+// nested operations report without coordinating.
+func (t *requestTracker) progressReporter(ctx context.Context, token mcp.ProgressToken) progress.Reporter {
+	srv := server.ServerFromContext(ctx)
+
+	var (
+		mu   sync.Mutex
+		last float64
+		sent bool
+	)
+	return func(value, total float64, message string) {
+		if srv == nil {
+			return
+		}
+
+		mu.Lock()
+		if sent && value <= last {
+			mu.Unlock()
+			return
+		}
+		last, sent = value, true
+		mu.Unlock()
+
+		params := map[string]any{
+			"progressToken": token,
+			"progress":      value,
+		}
+		if total > 0 {
+			params["total"] = total
+		}
+		if message != "" {
+			params["message"] = message
+		}
+		if err := srv.SendNotificationToClient(ctx, methodNotificationProgress, params); err != nil {
+			t.logger.Debug("Failed to send progress notification", zap.Error(err))
+		}
+	}
+}
+
+// requestKey scopes a JSON-RPC id to the session it was sent in. Numeric ids
+// may arrive as int64 or float64, %v prints both the same way.
+func requestKey(ctx context.Context, id any) string {
+	var sessionID string
+	if session := server.ClientSessionFromContext(ctx); session != nil {
+		sessionID = session.SessionID()
+	}
+	return fmt.Sprintf("%s/%v", sessionID, id)
+}
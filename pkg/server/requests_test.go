@@ -0,0 +1,91 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/progress"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type testSession struct {
+	id            string
+	notifications chan mcp.JSONRPCNotification
+}
+
+func (s *testSession) Initialize()       {}
+func (s *testSession) Initialized() bool { return true }
+func (s *testSession) SessionID() string { return s.id }
+func (s *testSession) NotificationChannel() chan<- mcp.JSONRPCNotification {
+	return s.notifications
+}
+
+func TestUnitRequestTracker(t *testing.T) {
+	tracker := newRequestTracker(zap.NewNop())
+	s := server.NewMCPServer("test", "0.0.0",
+		server.WithHooks(tracker.hooks()),
+		server.WithToolHandlerMiddleware(tracker.middleware),
+	)
+	s.AddNotificationHandler(methodNotificationCancelled, tracker.handleCancelled)
+
+	started := make(chan struct{})
+	s.AddTool(mcp.NewTool("slow"), func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		progress.Report(ctx, 1, 3, "first page")
+		progress.Report(ctx, 1, 3, "same page again")
+		progress.Report(ctx, 2, 3, "")
+		close(started)
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+
+	session := &testSession{id: "s1", notifications: make(chan mcp.JSONRPCNotification, 10)}
+	require.NoError(t, s.RegisterSession(context.Background(), session))
+	ctx := s.WithContext(context.Background(), session)
+
+	done := make(chan mcp.JSONRPCMessage, 1)
+	go func() {
+		done <- s.HandleMessage(ctx, json.RawMessage(`{"jsonrpc":"2.0","id":7,"method":"tools/call","params":{"name":"slow","_meta":{"progressToken":"tok"}}}`))
+	}()
+
+	select {
+	case <-started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("tool did not start")
+	}
+
+	// A different session cannot cancel the call.
+	other := &testSession{id: "s2", notifications: make(chan mcp.JSONRPCNotification, 1)}
+	require.NoError(t, s.RegisterSession(context.Background(), other))
+	s.HandleMessage(s.WithContext(context.Background(), other), json.RawMessage(`{"jsonrpc":"2.0","method":"notifications/cancelled","params":{"requestId":7}}`))
+	select {
+	case <-done:
+		t.Fatal("call cancelled by another session")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	s.HandleMessage(ctx, json.RawMessage(`{"jsonrpc":"2.0","method":"notifications/cancelled","params":{"requestId":7,"reason":"user aborted"}}`))
+
+	select {
+	case res := <-done:
+		_, isError := res.(mcp.JSONRPCError)
+		assert.True(t, isError)
+	case <-time.After(5 * time.Second):
+		t.Fatal("call was not cancelled")
+	}
+
+	require.Len(t, session.notifications, 2)
+	first := <-session.notifications
+	assert.Equal(t, methodNotificationProgress, first.Method)
+	assert.Equal(t, map[string]any{"progressToken": "tok", "progress": 1.0, "total": 3.0, "message": "first page"}, first.Params.AdditionalFields)
+	second := <-session.notifications
+	assert.Equal(t, 2.0, second.Params.AdditionalFields["progress"])
+
+	assert.Empty(t, tracker.ids)
+	assert.Empty(t, tracker.cancels)
+}
@@ -4,12 +4,15 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/korotovsky/slack-mcp-server/pkg/handler"
 	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/korotovsky/slack-mcp-server/pkg/realtime"
 	"github.com/korotovsky/slack-mcp-server/pkg/server/auth"
-	"github.com/korotovsky/slack-mcp-server/pkg/text"
 	"github.com/korotovsky/slack-mcp-server/pkg/version"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
@@ -17,21 +20,47 @@ import (
 )
 
 type MCPServer struct {
-	server *server.MCPServer
-	logger *zap.Logger
+	server      *server.MCPServer
+	interceptor *interceptor
+	keys        auth.KeyRing
+	logger      *zap.Logger
 }
 
-func NewMCPServer(provider *provider.ApiProvider, logger *zap.Logger) *MCPServer {
+func NewMCPServer(workspaces *provider.Workspaces, ingestor *realtime.Ingestor, logger *zap.Logger) *MCPServer {
+	tracker := newRequestTracker(logger)
+	router := newWorkspaceRouter(workspaces, ingestor, logger)
+	transport := workspaces.Transport()
+	subs := newSubscriptions()
+
+	hooks := tracker.hooks()
+	if transport != "http" {
+		// A streamable HTTP session outlives its event stream, it ends with
+		// a DELETE request instead, see interceptor.httpMiddleware.
+		hooks.AddOnUnregisterSession(func(_ context.Context, session server.ClientSession) {
+			subs.drop(session.SessionID())
+		})
+	}
+
 	s := server.NewMCPServer(
 		"Slack MCP Server",
 		version.Version,
 		server.WithLogging(),
 		server.WithRecovery(),
+		server.WithHooks(hooks),
+		// mcp-go sends notifications/tools/list_changed whenever tools are
+		// added or deleted. The tool set, including the allowlist and
+		// denylist, is fixed before serving, so it never fires today.
+		server.WithToolCapabilities(true),
+		server.WithResourceCapabilities(true, true),
+		// The tracker finds the request by its context, so it has to see
+		// the context exactly as mcp-go passes it.
+		server.WithToolHandlerMiddleware(tracker.middleware),
 		server.WithToolHandlerMiddleware(buildLoggerMiddleware(logger)),
-		server.WithToolHandlerMiddleware(auth.BuildMiddleware(provider.ServerTransport(), logger)),
+		server.WithToolHandlerMiddleware(buildOutputFormatMiddleware()),
+		server.WithToolHandlerMiddleware(auth.BuildMiddleware(transport, logger)),
 	)
 
-	conversationsHandler := handler.NewConversationsHandler(provider, logger)
+	s.AddNotificationHandler(methodNotificationCancelled, tracker.handleCancelled)
 
 	s.AddTool(mcp.NewTool("conversations_history",
 		mcp.WithDescription("Get messages from the channel (or DM) by channel_id, the last row/column in the response is used as 'cursor' parameter for pagination if not empty"),
@@ -50,7 +79,10 @@ func NewMCPServer(provider *provider.ApiProvider, logger *zap.Logger) *MCPServer
 			mcp.DefaultString("1d"),
 			mcp.Description("Limit of messages to fetch in format of maximum ranges of time (e.g. 1d - 1 day, 1w - 1 week, 30d - 30 days, 90d - 90 days which is a default limit for free tier history) or number of messages (e.g. 50). Must be empty when 'cursor' is provided."),
 		),
-	), conversationsHandler.ConversationsHistoryHandler)
+		withOutputFormat(),
+		router.withWorkspace(),
+		mcp.WithOutputSchema[handler.Page[handler.Message]](),
+	), router.conversations((*handler.ConversationsHandler).ConversationsHistoryHandler))
 
 	s.AddTool(mcp.NewTool("conversations_replies",
 		mcp.WithDescription("Get a thread of messages posted to a conversation by channelID and thread_ts, the last row/column in the response is used as 'cursor' parameter for pagination if not empty"),
@@ -73,7 +105,10 @@ func NewMCPServer(provider *provider.ApiProvider, logger *zap.Logger) *MCPServer
 			mcp.DefaultString("1d"),
 			mcp.Description("Limit of messages to fetch in format of maximum ranges of time (e.g. 1d - 1 day, 30d - 30 days, 90d - 90 days which is a default limit for free tier history) or number of messages (e.g. 50). Must be empty when 'cursor' is provided."),
 		),
-	), conversationsHandler.ConversationsRepliesHandler)
+		withOutputFormat(),
+		router.withWorkspace(),
+		mcp.WithOutputSchema[handler.Page[handler.Message]](),
+	), router.conversations((*handler.ConversationsHandler).ConversationsRepliesHandler))
 
 	s.AddTool(mcp.NewTool("conversations_add_message",
 		mcp.WithDescription("Add a message to a public channel, private channel, or direct message (DM, or IM) conversation by channel_id and thread_ts."),
@@ -91,7 +126,108 @@ func NewMCPServer(provider *provider.ApiProvider, logger *zap.Logger) *MCPServer
 			mcp.DefaultString("text/markdown"),
 			mcp.Description("Content type of the message. Default is 'text/markdown'. Allowed values: 'text/markdown', 'text/plain'."),
 		),
-	), conversationsHandler.ConversationsAddMessageHandler)
+		withOutputFormat(),
+		router.withWorkspace(),
+		mcp.WithOutputSchema[handler.Page[handler.Message]](),
+	), router.conversations((*handler.ConversationsHandler).ConversationsAddMessageHandler))
+
+	s.AddTool(mcp.NewTool("conversations_edit_message",
+		mcp.WithDescription("Edit a message previously posted by the authenticated user in a public channel, private channel, or direct message (DM, or IM) conversation. The new payload replaces the whole message."),
+		mcp.WithString("channel_id",
+			mcp.Required(),
+			mcp.Description("ID of the channel in format Cxxxxxxxxxx or its name starting with #... or @... aka #general or @username_dm."),
+		),
+		mcp.WithString("timestamp",
+			mcp.Required(),
+			mcp.Description("Timestamp of the message to edit in format 1234567890.123456."),
+		),
+		mcp.WithString("payload",
+			mcp.Required(),
+			mcp.Description("New message payload in specified content_type format. Example: 'Hello, world!' for text/plain or '# Hello, world!' for text/markdown."),
+		),
+		mcp.WithString("content_type",
+			mcp.DefaultString("text/markdown"),
+			mcp.Description("Content type of the message. Default is 'text/markdown'. Allowed values: 'text/markdown', 'text/plain'."),
+		),
+		withOutputFormat(),
+		router.withWorkspace(),
+		mcp.WithOutputSchema[handler.Page[handler.Message]](),
+	), router.conversations((*handler.ConversationsHandler).ConversationsEditMessageHandler))
+
+	s.AddTool(mcp.NewTool("conversations_delete_message",
+		mcp.WithDescription("Delete a message previously posted by the authenticated user in a public channel, private channel, or direct message (DM, or IM) conversation. Returns the deleted message."),
+		mcp.WithString("channel_id",
+			mcp.Required(),
+			mcp.Description("ID of the channel in format Cxxxxxxxxxx or its name starting with #... or @... aka #general or @username_dm."),
+		),
+		mcp.WithString("timestamp",
+			mcp.Required(),
+			mcp.Description("Timestamp of the message to delete in format 1234567890.123456."),
+		),
+		withOutputFormat(),
+		router.withWorkspace(),
+		mcp.WithOutputSchema[handler.Page[handler.Message]](),
+	), router.conversations((*handler.ConversationsHandler).ConversationsDeleteMessageHandler))
+
+	s.AddTool(mcp.NewTool("conversations_schedule_message",
+		mcp.WithDescription("Schedule a message to be posted later to a public channel, private channel, or direct message (DM, or IM) conversation by channel_id and thread_ts."),
+		mcp.WithString("channel_id",
+			mcp.Required(),
+			mcp.Description("ID of the channel in format Cxxxxxxxxxx or its name starting with #... or @... aka #general or @username_dm."),
+		),
+		mcp.WithString("post_at",
+			mcp.Required(),
+			mcp.Description("When to post the message. Example: '9am tomorrow', 'tomorrow at 14:30', '2025-10-01 9:00', 'in 2 hours' or RFC3339 '2025-10-01T09:00:00Z'. A bare time of day like '9am' means its next occurrence. Must be within 120 days."),
+		),
+		mcp.WithString("timezone",
+			mcp.Description("IANA timezone used to interpret post_at, e.g. 'Europe/Berlin'. If not provided, the Slack profile timezone of the authenticated user is used."),
+		),
+		mcp.WithString("thread_ts",
+			mcp.Description("Unique identifier of either a thread's parent message or a message in the thread_ts must be the timestamp in format 1234567890.123456 of an existing message with 0 or more replies. Optional, if not provided the message will be added to the channel itself, otherwise it will be added to the thread."),
+		),
+		mcp.WithString("payload",
+			mcp.Description("Message payload in specified content_type format. Example: 'Hello, world!' for text/plain or '# Hello, world!' for text/markdown."),
+		),
+		mcp.WithString("content_type",
+			mcp.DefaultString("text/markdown"),
+			mcp.Description("Content type of the message. Default is 'text/markdown'. Allowed values: 'text/markdown', 'text/plain'."),
+		),
+		withOutputFormat(),
+		router.withWorkspace(),
+		mcp.WithOutputSchema[handler.Page[handler.ScheduledMessage]](),
+	), router.conversations((*handler.ConversationsHandler).ConversationsScheduleMessageHandler))
+
+	s.AddTool(mcp.NewTool("conversations_list_scheduled_messages",
+		mcp.WithDescription("List pending scheduled messages of the authenticated user, optionally limited to a single channel."),
+		mcp.WithString("channel_id",
+			mcp.Description("ID of the channel in format Cxxxxxxxxxx or its name starting with #... or @... aka #general or @username_dm. If not provided, all channels are included."),
+		),
+		mcp.WithString("cursor",
+			mcp.Description("Cursor for pagination. Use the value of the last row and column in the response as next_cursor field returned from the previous request."),
+		),
+		mcp.WithNumber("limit",
+			mcp.DefaultNumber(100),
+			mcp.Description("The maximum number of items to return. Must be an integer between 1 and 100."),
+		),
+		withOutputFormat(),
+		router.withWorkspace(),
+		mcp.WithOutputSchema[handler.Page[handler.ScheduledMessage]](),
+	), router.conversations((*handler.ConversationsHandler).ConversationsListScheduledMessagesHandler))
+
+	s.AddTool(mcp.NewTool("conversations_delete_scheduled_message",
+		mcp.WithDescription("Cancel a pending scheduled message before it is posted."),
+		mcp.WithString("channel_id",
+			mcp.Required(),
+			mcp.Description("ID of the channel in format Cxxxxxxxxxx or its name starting with #... or @... aka #general or @username_dm."),
+		),
+		mcp.WithString("scheduled_message_id",
+			mcp.Required(),
+			mcp.Description("ID of the scheduled message as returned by conversations_schedule_message or conversations_list_scheduled_messages."),
+		),
+		withOutputFormat(),
+		router.withWorkspace(),
+		mcp.WithOutputSchema[handler.Page[handler.ScheduledMessage]](),
+	), router.conversations((*handler.ConversationsHandler).ConversationsDeleteScheduledMessageHandler))
 
 	s.AddTool(mcp.NewTool("conversations_search_messages",
 		mcp.WithDescription("Search messages in a public channel, private channel, or direct message (DM, or IM) conversation using filters. All filters are optional, if not provided then search_query is required."),
@@ -133,9 +269,53 @@ func NewMCPServer(provider *provider.ApiProvider, logger *zap.Logger) *MCPServer
 			mcp.DefaultNumber(20),
 			mcp.Description("The maximum number of items to return. Must be an integer between 1 and 100."),
 		),
-	), conversationsHandler.ConversationsSearchHandler)
+		withOutputFormat(),
+		router.withWorkspace(),
+		mcp.WithOutputSchema[handler.Page[handler.Message]](),
+	), router.conversations((*handler.ConversationsHandler).ConversationsSearchHandler))
+
+	s.AddTool(mcp.NewTool("unreads_list",
+		mcp.WithDescription("Get list of conversations (channels, DMs and group DMs) with unread messages, sorted by number of mentions and then by most recent activity. Optionally returns the unread messages themselves as a second CSV to catch up in one call."),
+		mcp.WithString("channel_types",
+			mcp.Description("Comma-separated channel types to include. Allowed values: 'mpim', 'im', 'public_channel', 'private_channel'. Example: 'im,mpim'. If not provided, all types are included."),
+		),
+		mcp.WithBoolean("mentions_only",
+			mcp.Description("If true, the response will include only conversations where the current user was mentioned. Default is boolean false."),
+			mcp.DefaultBool(false),
+		),
+		mcp.WithBoolean("include_messages",
+			mcp.Description("If true, the response will include a second CSV with unread messages from each returned conversation. Default is boolean false."),
+			mcp.DefaultBool(false),
+		),
+		mcp.WithNumber("messages_limit",
+			mcp.DefaultNumber(10),
+			mcp.Description("The maximum number of unread messages to fetch per conversation when 'include_messages' is true. Must be an integer between 1 and 100."),
+		),
+		mcp.WithNumber("limit",
+			mcp.DefaultNumber(50),
+			mcp.Description("The maximum number of conversations to return."),
+		),
+		withOutputFormat(),
+		router.withWorkspace(),
+		mcp.WithOutputSchema[handler.UnreadsPage](),
+	), router.conversations((*handler.ConversationsHandler).UnreadsListHandler))
 
-	channelsHandler := handler.NewChannelsHandler(provider, logger)
+	s.AddTool(mcp.NewTool("conversations_mark",
+		mcp.WithDescription("Mark a channel, DM or thread as read up to the given message, or up to the latest message if no timestamp is provided. Use it after processing conversations returned by unreads_list to leave the inbox clean. Disabled unless SLACK_MCP_MARK_TOOL is set."),
+		mcp.WithString("channel_id",
+			mcp.Required(),
+			mcp.Description("ID of the channel in format Cxxxxxxxxxx or its name starting with #... or @... aka #general or @username_dm."),
+		),
+		mcp.WithString("thread_ts",
+			mcp.Description("Timestamp of the parent message in format 1234567890.123456. If provided, the thread is marked as read instead of the channel. Requires a browser session token (xoxc/xoxd)."),
+		),
+		mcp.WithString("ts",
+			mcp.Description("Timestamp of the last read message in format 1234567890.123456. If not provided, the latest message in the channel or thread is used."),
+		),
+		withOutputFormat(),
+		router.withWorkspace(),
+		mcp.WithOutputSchema[handler.Page[handler.MarkedConversation]](),
+	), router.conversations((*handler.ConversationsHandler).ConversationsMarkHandler))
 
 	s.AddTool(mcp.NewTool("channels_list",
 		mcp.WithDescription("Get list of channels"),
@@ -153,54 +333,361 @@ func NewMCPServer(provider *provider.ApiProvider, logger *zap.Logger) *MCPServer
 		mcp.WithString("cursor",
 			mcp.Description("Cursor for pagination. Use the value of the last row and column in the response as next_cursor field returned from the previous request."),
 		),
-	), channelsHandler.ChannelsHandler)
+		withOutputFormat(),
+		router.withWorkspace(),
+		mcp.WithOutputSchema[handler.Page[handler.Channel]](),
+	), router.channels((*handler.ChannelsHandler).ChannelsHandler))
 
-	logger.Info("Authenticating with Slack API...",
-		zap.String("context", "console"),
-	)
-	ar, err := provider.Slack().AuthTest()
-	if err != nil {
-		logger.Fatal("Failed to authenticate with Slack",
+	s.AddTool(mcp.NewTool("channel_info",
+		mcp.WithDescription("Get detailed information about a channel: type, topic, purpose, member count, creator, creation date, archived/shared flags, posting restrictions, canvas, tabs and previous names. Useful to explain what a channel is for and who owns it."),
+		mcp.WithString("channel_id",
+			mcp.Required(),
+			mcp.Description("ID of the channel in format Cxxxxxxxxxx or its name starting with #... or @... aka #general or @username_dm."),
+		),
+		withOutputFormat(),
+		router.withWorkspace(),
+		mcp.WithOutputSchema[handler.Page[handler.ChannelDetails]](),
+	), router.channels((*handler.ChannelsHandler).ChannelInfoHandler))
+
+	s.AddTool(mcp.NewTool("channel_members",
+		mcp.WithDescription("List members of a channel with their names, titles and bot/guest/admin flags, or check whether specific users are members of the channel when 'users' is provided."),
+		mcp.WithString("channel_id",
+			mcp.Required(),
+			mcp.Description("ID of the channel in format Cxxxxxxxxxx or its name starting with #... or @... aka #general or @username_dm."),
+		),
+		mcp.WithString("users",
+			mcp.Description("Comma-separated list of user IDs or @handles to check membership for. Example: 'U1234567890,@jane'. If provided, the response contains one row per user with the isMember column set and pagination is ignored."),
+		),
+		mcp.WithBoolean("include_bots",
+			mcp.Description("If false, bots and app users are left out of the list. Default is boolean true."),
+			mcp.DefaultBool(true),
+		),
+		mcp.WithNumber("limit",
+			mcp.DefaultNumber(100),
+			mcp.Description("The maximum number of members to return. Must be an integer between 1 and 1000."),
+		),
+		mcp.WithString("cursor",
+			mcp.Description("Cursor for pagination. Use the value of the last row and column in the response as next_cursor field returned from the previous request."),
+		),
+		withOutputFormat(),
+		router.withWorkspace(),
+		mcp.WithOutputSchema[handler.Page[handler.ChannelMember]](),
+	), router.channels((*handler.ChannelsHandler).ChannelMembersHandler))
+
+	s.AddTool(mcp.NewTool("users_search",
+		mcp.WithDescription("Search users by ID, @handle, real name, display name, email or title with fuzzy matching. Useful to find out who somebody is (e.g. 'jane payments') before filtering messages by user."),
+		mcp.WithString("query",
+			mcp.Required(),
+			mcp.Description("Search query. Example: 'U1234567890', '@jane', 'jane.doe@example.com', 'Jane Doe' or 'jane payments' to match words across name and title."),
+		),
+		mcp.WithBoolean("include_deleted",
+			mcp.Description("If true, the response will include deactivated users. Default is boolean false."),
+			mcp.DefaultBool(false),
+		),
+		mcp.WithBoolean("include_bots",
+			mcp.Description("If true, the response will include bots and app users. Default is boolean false."),
+			mcp.DefaultBool(false),
+		),
+		mcp.WithNumber("limit",
+			mcp.DefaultNumber(10),
+			mcp.Description("The maximum number of users to return. Must be an integer between 1 and 100."),
+		),
+		withOutputFormat(),
+		router.withWorkspace(),
+		mcp.WithOutputSchema[handler.Page[handler.UserDetails]](),
+	), router.users((*handler.UsersHandler).UsersSearchHandler))
+
+	s.AddTool(mcp.NewTool("users_info",
+		mcp.WithDescription("Get profile details of users by their IDs or @handles: names, email, title, timezone, status, bot/admin/guest flags and whether the account is deactivated."),
+		mcp.WithString("users",
+			mcp.Required(),
+			mcp.Description("Comma-separated list of user IDs or @handles. Example: 'U1234567890,@jane'."),
+		),
+		withOutputFormat(),
+		router.withWorkspace(),
+		mcp.WithOutputSchema[handler.Page[handler.UserDetails]](),
+	), router.users((*handler.UsersHandler).UsersInfoHandler))
+
+	s.AddTool(mcp.NewTool("users_status",
+		mcp.WithDescription("Get presence (active/away), custom status with its expiration, do-not-disturb windows and local time of users. Useful to tell whether somebody is out of office or asleep before pinging them."),
+		mcp.WithString("users",
+			mcp.Required(),
+			mcp.Description("Comma-separated list of up to 20 user IDs or @handles. Example: 'U1234567890,@jane'."),
+		),
+		withOutputFormat(),
+		router.withWorkspace(),
+		mcp.WithOutputSchema[handler.Page[handler.UserStatus]](),
+	), router.users((*handler.UsersHandler).UsersStatusHandler))
+
+	s.AddTool(mcp.NewTool("reactions_add",
+		mcp.WithDescription("Add an emoji reaction to a message in a public channel, private channel, or direct message (DM, or IM) conversation. Returns the reactions on the message after the change."),
+		mcp.WithString("channel_id",
+			mcp.Required(),
+			mcp.Description("ID of the channel in format Cxxxxxxxxxx or its name starting with #... or @... aka #general or @username_dm."),
+		),
+		mcp.WithString("timestamp",
+			mcp.Required(),
+			mcp.Description("Timestamp of the message in format 1234567890.123456."),
+		),
+		mcp.WithString("emoji",
+			mcp.Required(),
+			mcp.Description("Emoji name without surrounding colons, e.g. 'thumbsup', 'eyes' or 'white_check_mark'."),
+		),
+		withOutputFormat(),
+		router.withWorkspace(),
+		mcp.WithOutputSchema[handler.Page[handler.Reaction]](),
+	), router.conversations((*handler.ConversationsHandler).ReactionsAddHandler))
+
+	s.AddTool(mcp.NewTool("reactions_remove",
+		mcp.WithDescription("Remove an emoji reaction previously added by the current user from a message. Returns the reactions on the message after the change."),
+		mcp.WithString("channel_id",
+			mcp.Required(),
+			mcp.Description("ID of the channel in format Cxxxxxxxxxx or its name starting with #... or @... aka #general or @username_dm."),
+		),
+		mcp.WithString("timestamp",
+			mcp.Required(),
+			mcp.Description("Timestamp of the message in format 1234567890.123456."),
+		),
+		mcp.WithString("emoji",
+			mcp.Required(),
+			mcp.Description("Emoji name without surrounding colons, e.g. 'thumbsup', 'eyes' or 'white_check_mark'."),
+		),
+		withOutputFormat(),
+		router.withWorkspace(),
+		mcp.WithOutputSchema[handler.Page[handler.Reaction]](),
+	), router.conversations((*handler.ConversationsHandler).ReactionsRemoveHandler))
+
+	s.AddTool(mcp.NewTool("reactions_get",
+		mcp.WithDescription("List reactions on a message with the users who reacted, one row per emoji and user."),
+		mcp.WithString("channel_id",
+			mcp.Required(),
+			mcp.Description("ID of the channel in format Cxxxxxxxxxx or its name starting with #... or @... aka #general or @username_dm."),
+		),
+		mcp.WithString("timestamp",
+			mcp.Required(),
+			mcp.Description("Timestamp of the message in format 1234567890.123456."),
+		),
+		withOutputFormat(),
+		router.withWorkspace(),
+		mcp.WithOutputSchema[handler.Page[handler.Reaction]](),
+	), router.conversations((*handler.ConversationsHandler).ReactionsGetHandler))
+
+	s.AddTool(mcp.NewTool("files_search",
+		mcp.WithDescription("Search files shared in public channels, private channels, or direct message (DM, or IM) conversations using filters. All filters are optional, if not provided then search_query is required."),
+		mcp.WithString("search_query",
+			mcp.Description("Search query to filter files by name, title or content. Example: 'quarterly report' or 'type:pdf'."),
+		),
+		mcp.WithString("filter_in_channel",
+			mcp.Description("Filter files in a specific channel by its ID or name. Example: 'C1234567890' or '#general'. If not provided, all channels will be searched."),
+		),
+		mcp.WithString("filter_in_im_or_mpim",
+			mcp.Description("Filter files in a direct message (DM) or multi-person direct message (MPIM) conversation by its ID or name. Example: 'D1234567890' or '@username_dm'. If not provided, all DMs and MPIMs will be searched."),
+		),
+		mcp.WithString("filter_users_from",
+			mcp.Description("Filter files shared by a specific user by their ID or display name. Example: 'U1234567890' or '@username'. If not provided, all users will be searched."),
+		),
+		mcp.WithString("filter_date_before",
+			mcp.Description("Filter files shared before a specific date in format 'YYYY-MM-DD'. Example: '2023-10-01', 'July', 'Yesterday' or 'Today'. If not provided, all dates will be searched."),
+		),
+		mcp.WithString("filter_date_after",
+			mcp.Description("Filter files shared after a specific date in format 'YYYY-MM-DD'. Example: '2023-10-01', 'July', 'Yesterday' or 'Today'. If not provided, all dates will be searched."),
+		),
+		mcp.WithString("filter_date_on",
+			mcp.Description("Filter files shared on a specific date in format 'YYYY-MM-DD'. Example: '2023-10-01', 'July', 'Yesterday' or 'Today'. If not provided, all dates will be searched."),
+		),
+		mcp.WithString("filter_date_during",
+			mcp.Description("Filter files shared during a specific period in format 'YYYY-MM-DD'. Example: 'July', 'Yesterday' or 'Today'. If not provided, all dates will be searched."),
+		),
+		mcp.WithString("cursor",
+			mcp.DefaultString(""),
+			mcp.Description("Cursor for pagination. Use the value of the last row and column in the response as next_cursor field returned from the previous request."),
+		),
+		mcp.WithNumber("limit",
+			mcp.DefaultNumber(20),
+			mcp.Description("The maximum number of items to return. Must be an integer between 1 and 100."),
+		),
+		withOutputFormat(),
+		router.withWorkspace(),
+		mcp.WithOutputSchema[handler.Page[handler.File]](),
+	), router.conversations((*handler.ConversationsHandler).FilesSearchHandler))
+
+	s.AddTool(mcp.NewTool("files_get",
+		mcp.WithDescription("Download a file by its ID. Returns file metadata as CSV followed by the file content: text files as text and images as image content. Other file types and files larger than SLACK_MCP_FILES_MAX_SIZE are rejected."),
+		mcp.WithString("file_id",
+			mcp.Required(),
+			mcp.Description("ID of the file in format Fxxxxxxxxxx, as returned by files_search or the files column of message tools."),
+		),
+		withOutputFormat(),
+		router.withWorkspace(),
+		mcp.WithOutputSchema[handler.File](),
+	), router.conversations((*handler.ConversationsHandler).FilesGetHandler))
+
+	if ingestor != nil {
+		s.AddTool(mcp.NewTool("events_recent",
+			mcp.WithDescription("Get events received over the real-time connection since the server started, newest first: messages, edits, deletions, reactions, channel and user changes. Cheaper than polling conversations_history to find out what happened recently."),
+			mcp.WithString("channel_id",
+				mcp.Description("Only return events of this channel. ID in format Cxxxxxxxxxx or its name starting with #... or @... aka #general or @username_dm."),
+			),
+			mcp.WithString("types",
+				mcp.Description("Comma-separated list of event types to return, e.g. 'message,reaction_added'. Default is all types."),
+			),
+			mcp.WithNumber("limit",
+				mcp.DefaultNumber(50),
+				mcp.Description("The maximum number of events to return."),
+			),
+			withOutputFormat(),
+			router.withWorkspace(),
+			mcp.WithOutputSchema[handler.Page[handler.RealtimeEvent]](),
+		), router.events((*handler.EventsHandler).EventsRecentHandler))
+	}
+
+	if err := applyToolsFilter(s, os.Getenv("SLACK_MCP_ENABLED_TOOLS"), os.Getenv("SLACK_MCP_DISABLED_TOOLS"), logger); err != nil {
+		logger.Fatal("error in SLACK_MCP_ENABLED_TOOLS or SLACK_MCP_DISABLED_TOOLS",
 			zap.String("context", "console"),
 			zap.Error(err),
 		)
 	}
 
-	logger.Info("Successfully authenticated with Slack",
-		zap.String("context", "console"),
-		zap.String("team", ar.Team),
-		zap.String("user", ar.User),
-		zap.String("enterprise", ar.EnterpriseID),
-		zap.String("url", ar.URL),
-	)
+	tools := s.ListTools()
+	promptsHandler := handler.NewPromptsHandler(func(name string) bool {
+		_, ok := tools[name]
+		return ok
+	}, logger)
 
-	ws, err := text.Workspace(ar.URL)
-	if err != nil {
-		logger.Fatal("Failed to parse workspace from URL",
+	prompts := []server.ServerPrompt{
+		{Prompt: mcp.NewPrompt("summarize_channel",
+			mcp.WithPromptDescription("Summarize a channel for the last N days: key decisions, open questions and action items."),
+			mcp.WithArgument("channel",
+				mcp.RequiredArgument(),
+				mcp.ArgumentDescription("Channel ID or name, e.g. C1234567890 or #general."),
+			),
+			mcp.WithArgument("period",
+				mcp.ArgumentDescription("Period to summarize, e.g. 1d, 2w or 1m. Default is 7d."),
+			),
+		), Handler: promptsHandler.SummarizeChannelPrompt},
+		{Prompt: mcp.NewPrompt("catch_up_mentions",
+			mcp.WithPromptDescription("Catch me up on unread mentions and direct messages, grouped by what needs a reply."),
+			mcp.WithArgument("period",
+				mcp.ArgumentDescription("How far back to look, e.g. 1d or 1w. Default is 1d."),
+			),
+			mcp.WithArgument("mark_read",
+				mcp.ArgumentDescription("Set to true to mark processed conversations as read. Default is false."),
+			),
+		), Handler: promptsHandler.CatchUpMentionsPrompt},
+		{Prompt: mcp.NewPrompt("draft_thread_reply",
+			mcp.WithPromptDescription("Draft a reply to a thread and post it only after confirmation."),
+			mcp.WithArgument("channel",
+				mcp.RequiredArgument(),
+				mcp.ArgumentDescription("Channel ID or name the thread belongs to, e.g. C1234567890 or #general."),
+			),
+			mcp.WithArgument("thread_ts",
+				mcp.RequiredArgument(),
+				mcp.ArgumentDescription("Timestamp of the parent message in format 1234567890.123456."),
+			),
+			mcp.WithArgument("intent",
+				mcp.ArgumentDescription("What the reply should achieve, e.g. 'agree and propose Friday for the release'."),
+			),
+			mcp.WithArgument("tone",
+				mcp.ArgumentDescription("Tone of the reply, e.g. formal. Default is friendly and concise."),
+			),
+		), Handler: promptsHandler.DraftThreadReplyPrompt},
+		{Prompt: mcp.NewPrompt("standup_digest",
+			mcp.WithPromptDescription("Weekly standup digest of one or more channels, grouped by person: done, in progress and blockers."),
+			mcp.WithArgument("channels",
+				mcp.RequiredArgument(),
+				mcp.ArgumentDescription("Comma-separated channel IDs or names, e.g. #team-backend,#team-frontend."),
+			),
+			mcp.WithArgument("period",
+				mcp.ArgumentDescription("Period to cover, e.g. 1w or 2w. Default is 1w."),
+			),
+		), Handler: promptsHandler.StandupDigestPrompt},
+	}
+
+	var hiddenPrompts []string
+	for _, p := range prompts {
+		if promptsHandler.Available(p.Prompt.Name) {
+			s.AddPrompts(p)
+		} else {
+			hiddenPrompts = append(hiddenPrompts, p.Prompt.Name)
+		}
+	}
+	if len(hiddenPrompts) > 0 {
+		logger.Info("Prompts disabled with the tools they rely on",
 			zap.String("context", "console"),
-			zap.String("url", ar.URL),
-			zap.Error(err),
+			zap.Strings("prompts", hiddenPrompts),
 		)
 	}
 
+	for _, ws := range router.list {
+		addResources(s, ws)
+		ws.provider.OnCacheChange(buildResourceUpdatedNotifier(s, subs, ws.id, logger))
+	}
+	if ingestor != nil {
+		ingestor.OnEvent(buildEventNotifier(s, subs, router.defaultWorkspace().id, logger))
+	}
+
+	var keys auth.KeyRing
+	if path := os.Getenv("SLACK_MCP_API_KEYS_FILE"); path != "" && workspaces.PerSession() {
+		var err error
+		keys, err = auth.LoadKeyRing(path)
+		if err != nil {
+			logger.Fatal("Failed to load SLACK_MCP_API_KEYS_FILE",
+				zap.String("context", "console"),
+				zap.Error(err),
+			)
+		}
+	}
+
+	return &MCPServer{
+		server: s,
+		interceptor: &interceptor{
+			router:        router,
+			subscriptions: subs,
+			transport:     transport,
+			logger:        logger,
+		},
+		keys:   keys,
+		logger: logger,
+	}
+}
+
+// addResources registers the directories and resource templates of ws under
+// its slack://<ws>/ prefix.
+func addResources(s *server.MCPServer, ws *workspace) {
 	s.AddResource(mcp.NewResource(
-		"slack://"+ws+"/channels",
+		"slack://"+ws.id+"/channels",
 		"Directory of Slack channels",
 		mcp.WithResourceDescription("This resource provides a directory of Slack channels."),
 		mcp.WithMIMEType("text/csv"),
-	), channelsHandler.ChannelsResource)
+	), ws.channels.ChannelsResource)
 
 	s.AddResource(mcp.NewResource(
-		"slack://"+ws+"/users",
+		"slack://"+ws.id+"/users",
 		"Directory of Slack users",
 		mcp.WithResourceDescription("This resource provides a directory of Slack users."),
 		mcp.WithMIMEType("text/csv"),
-	), conversationsHandler.UsersResource)
+	), ws.conversations.UsersResource)
 
-	return &MCPServer{
-		server: s,
-		logger: logger,
-	}
+	s.AddResourceTemplate(mcp.NewResourceTemplate(
+		"slack://"+ws.id+"/channels/{channel_id}/history",
+		"Slack channel history",
+		mcp.WithTemplateDescription("Latest messages of a channel or DM, as returned by conversations_history. channel_id is a channel ID or a URL-encoded name like %23general or %40username_dm."),
+		mcp.WithTemplateMIMEType("text/csv"),
+	), ws.conversations.ChannelHistoryResource)
+
+	s.AddResourceTemplate(mcp.NewResourceTemplate(
+		"slack://"+ws.id+"/channels/{channel_id}/threads/{thread_ts}",
+		"Slack thread",
+		mcp.WithTemplateDescription("Messages of a thread, as returned by conversations_replies. thread_ts is the timestamp of the parent message in format 1234567890.123456."),
+		mcp.WithTemplateMIMEType("text/csv"),
+	), ws.conversations.ChannelThreadResource)
+
+	s.AddResourceTemplate(mcp.NewResourceTemplate(
+		"slack://"+ws.id+"/users/{user_id}",
+		"Slack user profile",
+		mcp.WithTemplateDescription("Profile of a single user, as returned by users_info. user_id is a user ID or a URL-encoded handle like %40jane."),
+		mcp.WithTemplateMIMEType("text/csv"),
+	), ws.users.UserResource)
 }
 
 func (s *MCPServer) ServeSSE(addr string) *server.SSEServer {
@@ -211,14 +698,16 @@ func (s *MCPServer) ServeSSE(addr string) *server.SSEServer {
 		zap.String("commit_hash", version.CommitHash),
 		zap.String("address", addr),
 	)
-	return server.NewSSEServer(s.server,
+	httpServer := &http.Server{}
+	sseServer := server.NewSSEServer(s.server,
 		server.WithBaseURL(fmt.Sprintf("http://%s", addr)),
+		server.WithHTTPServer(httpServer),
 		server.WithSSEContextFunc(func(ctx context.Context, r *http.Request) context.Context {
-			ctx = auth.AuthFromRequest(s.logger)(ctx, r)
-
-			return ctx
+			return s.httpContext(ctx, r)
 		}),
 	)
+	httpServer.Handler = s.interceptor.httpMiddleware(sseServer, sseServer.CompleteMessagePath(), s.httpContext, sseResponder(sseServer))
+	return sseServer
 }
 
 func (s *MCPServer) ServeHTTP(addr string) *server.StreamableHTTPServer {
@@ -229,14 +718,29 @@ func (s *MCPServer) ServeHTTP(addr string) *server.StreamableHTTPServer {
 		zap.String("commit_hash", version.CommitHash),
 		zap.String("address", addr),
 	)
-	return server.NewStreamableHTTPServer(s.server,
+	httpServer := &http.Server{}
+	streamableServer := server.NewStreamableHTTPServer(s.server,
 		server.WithEndpointPath("/mcp"),
+		server.WithStreamableHTTPServer(httpServer),
 		server.WithHTTPContextFunc(func(ctx context.Context, r *http.Request) context.Context {
-			ctx = auth.AuthFromRequest(s.logger)(ctx, r)
-
-			return ctx
+			return s.httpContext(ctx, r)
 		}),
 	)
+	mux := http.NewServeMux()
+	mux.Handle("/mcp", s.interceptor.httpMiddleware(streamableServer, "/mcp", s.httpContext, writeJSONResponse))
+	httpServer.Handler = mux
+	return streamableServer
+}
+
+// httpContext carries the API key and, with per-session credentials, the
+// Slack credentials of an HTTP request.
+func (s *MCPServer) httpContext(ctx context.Context, r *http.Request) context.Context {
+	ctx = auth.AuthFromRequest(s.logger)(ctx, r)
+	if s.interceptor.router.tenants != nil {
+		ctx = auth.CredentialsFromRequest(s.keys, s.logger)(ctx, r)
+	}
+
+	return ctx
 }
 
 func (s *MCPServer) ServeStdio() error {
@@ -245,13 +749,66 @@ func (s *MCPServer) ServeStdio() error {
 		zap.String("build_time", version.BuildTime),
 		zap.String("commit_hash", version.CommitHash),
 	)
-	err := server.ServeStdio(s.server)
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, syscall.SIGINT)
+	defer stop()
+
+	err := s.interceptor.serveStdio(ctx, server.NewStdioServer(s.server), os.Stdin, os.Stdout)
 	if err != nil {
 		s.logger.Error("STDIO server error", zap.Error(err))
 	}
 	return err
 }
 
+// buildResourceUpdatedNotifier tells the sessions subscribed to a directory
+// resource to re-read it after a cache refresh changed it.
+func buildResourceUpdatedNotifier(s *server.MCPServer, subs *subscriptions, ws string, logger *zap.Logger) func(provider.CacheKind) {
+	return func(kind provider.CacheKind) {
+		subs.notify(s, "slack://"+ws+"/"+string(kind), logger)
+	}
+}
+
+// buildEventNotifier tells the sessions subscribed to the history, or the
+// thread when there is one, of a channel that received a real-time event to
+// re-read it.
+func buildEventNotifier(s *server.MCPServer, subs *subscriptions, ws string, logger *zap.Logger) func(realtime.Event) {
+	return func(e realtime.Event) {
+		if e.ChannelID == "" {
+			return
+		}
+		uris := []string{"slack://" + ws + "/channels/" + e.ChannelID + "/history"}
+		if e.ThreadTs != "" {
+			uris = append(uris, "slack://"+ws+"/channels/"+e.ChannelID+"/threads/"+e.ThreadTs)
+		}
+		for _, uri := range uris {
+			subs.notify(s, uri, logger.With(zap.String("event", e.Type)))
+		}
+	}
+}
+
+// withOutputFormat adds the output_format parameter accepted by every tool.
+func withOutputFormat() mcp.ToolOption {
+	return mcp.WithString("output_format",
+		mcp.Enum(handler.OutputFormatCSV, handler.OutputFormatJSON),
+		mcp.DefaultString(handler.OutputFormatCSV),
+		mcp.Description("Format of the text result: 'csv' or 'json'. The structured content is JSON either way, with the pagination cursor and has_more as top-level fields."),
+	)
+}
+
+// buildOutputFormatMiddleware rejects an unknown output_format before the
+// handler runs, so that write tools do not act and then fail.
+func buildOutputFormatMiddleware() server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			switch format := req.GetString("output_format", handler.OutputFormatCSV); format {
+			case handler.OutputFormatCSV, handler.OutputFormatJSON:
+				return next(ctx, req)
+			default:
+				return nil, fmt.Errorf("invalid output_format %q, allowed values: %s, %s", format, handler.OutputFormatCSV, handler.OutputFormatJSON)
+			}
+		}
+	}
+}
+
 func buildLoggerMiddleware(logger *zap.Logger) server.ToolHandlerMiddleware {
 	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
 		return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -0,0 +1,131 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"go.uber.org/zap"
+)
+
+const (
+	methodResourcesSubscribe   = "resources/subscribe"
+	methodResourcesUnsubscribe = "resources/unsubscribe"
+)
+
+// subscriptions keeps the resource URIs every session subscribed to, so that
+// resources/updated only reaches the sessions that asked for it.
+type subscriptions struct {
+	mu sync.Mutex
+	// byURI maps a resource URI to the IDs of the sessions subscribed to it.
+	byURI map[string]map[string]struct{}
+}
+
+func newSubscriptions() *subscriptions {
+	return &subscriptions{byURI: make(map[string]map[string]struct{})}
+}
+
+func (s *subscriptions) subscribe(sessionID, uri string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sessions, ok := s.byURI[uri]
+	if !ok {
+		sessions = make(map[string]struct{})
+		s.byURI[uri] = sessions
+	}
+	sessions[sessionID] = struct{}{}
+}
+
+func (s *subscriptions) unsubscribe(sessionID, uri string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.byURI[uri], sessionID)
+	if len(s.byURI[uri]) == 0 {
+		delete(s.byURI, uri)
+	}
+}
+
+// drop forgets every subscription of a session that ended.
+func (s *subscriptions) drop(sessionID string) {
+	if sessionID == "" {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for uri, sessions := range s.byURI {
+		delete(sessions, sessionID)
+		if len(sessions) == 0 {
+			delete(s.byURI, uri)
+		}
+	}
+}
+
+// subscribers returns the IDs of the sessions subscribed to uri.
+func (s *subscriptions) subscribers(uri string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ids := make([]string, 0, len(s.byURI[uri]))
+	for id := range s.byURI[uri] {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// notify sends notifications/resources/updated for uri to the sessions
+// subscribed to it.
+func (s *subscriptions) notify(srv *server.MCPServer, uri string, logger *zap.Logger) {
+	for _, id := range s.subscribers(uri) {
+		logger.Debug("Sending resource updated notification", zap.String("uri", uri), zap.String("session", id))
+		err := srv.SendNotificationToSpecificClient(id, mcp.MethodNotificationResourceUpdated, map[string]any{
+			"uri": uri,
+		})
+		if err != nil {
+			logger.Debug("Failed to send resource updated notification", zap.String("uri", uri), zap.String("session", id), zap.Error(err))
+		}
+	}
+}
+
+// subscribe answers resources/subscribe.
+func (c *interceptor) subscribe(ctx context.Context, id mcp.RequestId, raw []byte) mcp.JSONRPCMessage {
+	var request mcp.SubscribeRequest
+	if err := json.Unmarshal(raw, &request); err != nil {
+		return mcp.NewJSONRPCError(id, mcp.INVALID_PARAMS, err.Error(), nil)
+	}
+	return c.updateSubscription(ctx, id, request.Params.URI, c.subscriptions.subscribe)
+}
+
+// unsubscribe answers resources/unsubscribe.
+func (c *interceptor) unsubscribe(ctx context.Context, id mcp.RequestId, raw []byte) mcp.JSONRPCMessage {
+	var request mcp.UnsubscribeRequest
+	if err := json.Unmarshal(raw, &request); err != nil {
+		return mcp.NewJSONRPCError(id, mcp.INVALID_PARAMS, err.Error(), nil)
+	}
+	return c.updateSubscription(ctx, id, request.Params.URI, c.subscriptions.unsubscribe)
+}
+
+func (c *interceptor) updateSubscription(ctx context.Context, id mcp.RequestId, uri string, update func(sessionID, uri string)) mcp.JSONRPCMessage {
+	if uri == "" {
+		return mcp.NewJSONRPCError(id, mcp.INVALID_PARAMS, "uri is required", nil)
+	}
+	sessionID := sessionIDFromContext(ctx)
+	if sessionID == "" {
+		return mcp.NewJSONRPCError(id, mcp.INVALID_REQUEST, "subscriptions require a session", nil)
+	}
+
+	update(sessionID, uri)
+	c.logger.Debug("Updated resource subscription", zap.String("uri", uri), zap.String("session", sessionID))
+	return mcp.JSONRPCResponse{
+		JSONRPC: mcp.JSONRPC_VERSION,
+		ID:      id,
+		Result:  mcp.EmptyResult{},
+	}
+}
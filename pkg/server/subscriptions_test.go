@@ -0,0 +1,99 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestUnitSubscriptions(t *testing.T) {
+	subs := newSubscriptions()
+	subs.subscribe("s1", "slack://demo/users")
+	subs.subscribe("s2", "slack://demo/users")
+	subs.subscribe("s2", "slack://demo/channels")
+
+	assert.Equal(t, []string{"s1", "s2"}, subs.subscribers("slack://demo/users"))
+	assert.Empty(t, subs.subscribers("slack://demo/channels/C1/history"))
+
+	subs.unsubscribe("s1", "slack://demo/users")
+	assert.Equal(t, []string{"s2"}, subs.subscribers("slack://demo/users"))
+
+	subs.drop("s2")
+	assert.Empty(t, subs.subscribers("slack://demo/users"))
+	assert.Empty(t, subs.subscribers("slack://demo/channels"))
+	assert.Empty(t, subs.byURI)
+}
+
+func TestUnitSubscriptionsStdio(t *testing.T) {
+	c := newTestInterceptor(t, "stdio")
+	s := server.NewMCPServer("test", "0.0.0", server.WithResourceCapabilities(true, true))
+
+	stdinR, stdinW := io.Pipe()
+	stdoutR, stdoutW := io.Pipe()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		_ = c.serveStdio(ctx, server.NewStdioServer(s), stdinR, stdoutW)
+	}()
+
+	scanner := bufio.NewScanner(stdoutR)
+	send := func(line string) map[string]json.RawMessage {
+		go func() {
+			_, _ = io.WriteString(stdinW, line+"\n")
+		}()
+		require.True(t, scanner.Scan())
+		var msg map[string]json.RawMessage
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &msg))
+		return msg
+	}
+
+	send(`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-03-26","capabilities":{},"clientInfo":{"name":"test","version":"0.0.0"}}}`)
+
+	res := send(`{"jsonrpc":"2.0","id":2,"method":"resources/subscribe","params":{"uri":"slack://demo/users"}}`)
+	assert.JSONEq(t, `{}`, string(res["result"]))
+	assert.Equal(t, []string{stdioSessionID}, c.subscriptions.subscribers("slack://demo/users"))
+
+	c.subscriptions.notify(s, "slack://demo/channels", zap.NewNop())
+	c.subscriptions.notify(s, "slack://demo/users", zap.NewNop())
+	require.True(t, scanner.Scan())
+	assert.JSONEq(t, `{"jsonrpc":"2.0","method":"notifications/resources/updated","params":{"uri":"slack://demo/users"}}`, scanner.Text())
+
+	res = send(`{"jsonrpc":"2.0","id":3,"method":"resources/unsubscribe","params":{"uri":"slack://demo/users"}}`)
+	assert.JSONEq(t, `{}`, string(res["result"]))
+	assert.Empty(t, c.subscriptions.subscribers("slack://demo/users"))
+
+	res = send(`{"jsonrpc":"2.0","id":4,"method":"resources/subscribe","params":{}}`)
+	assert.Contains(t, string(res["error"]), "uri is required")
+}
+
+func TestUnitSubscriptionsHTTPMiddleware(t *testing.T) {
+	c := newTestInterceptor(t, "stdio")
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	h := c.httpMiddleware(next, "/mcp", func(ctx context.Context, _ *http.Request) context.Context { return ctx }, writeJSONResponse)
+
+	req := httptest.NewRequest(http.MethodPost, "/mcp", strings.NewReader(
+		`{"jsonrpc":"2.0","id":1,"method":"resources/subscribe","params":{"uri":"slack://demo/channels"}}`,
+	))
+	req.Header.Set(server.HeaderKeySessionID, "session-1")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	assert.JSONEq(t, `{"jsonrpc":"2.0","id":1,"result":{}}`, rec.Body.String())
+	assert.Equal(t, []string{"session-1"}, c.subscriptions.subscribers("slack://demo/channels"))
+
+	req = httptest.NewRequest(http.MethodDelete, "/mcp", nil)
+	req.Header.Set(server.HeaderKeySessionID, "session-1")
+	h.ServeHTTP(httptest.NewRecorder(), req)
+	assert.Empty(t, c.subscriptions.subscribers("slack://demo/channels"))
+}
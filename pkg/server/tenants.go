@@ -0,0 +1,166 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/korotovsky/slack-mcp-server/pkg/server/auth"
+	"go.uber.org/zap"
+)
+
+// tenantIdleTTL is how long the provider of credentials nobody used is kept.
+const tenantIdleTTL = time.Hour
+
+// tenantEvictionInterval is how often idle workspaces are looked for, also
+// when no session connects.
+const tenantEvictionInterval = tenantIdleTTL / 4
+
+var errMissingCredentials = errors.New("this server requires Slack credentials per session: send an API key mapped to them, or the " + auth.HeaderSlackToken + " header (and " + auth.HeaderSlackCookie + " for xoxc tokens)")
+
+// tenants keeps a workspace, and so an isolated provider and caches, per set
+// of Slack credentials supplied by HTTP sessions. Sessions sending the same
+// credentials share it.
+type tenants struct {
+	transport string
+	logger    *zap.Logger
+	// refreshInterval is how often the caches of every workspace are
+	// reloaded, as for the configured workspaces.
+	refreshInterval time.Duration
+	// connect creates the workspace of new credentials. Its background work
+	// stops once ctx is done, when the workspace is dropped.
+	connect func(context.Context, provider.Credentials) (*workspace, error)
+
+	mu      sync.Mutex
+	entries map[string]*tenant
+}
+
+type tenant struct {
+	ready    chan struct{}
+	ws       *workspace
+	err      error
+	lastUsed time.Time
+	// stop cancels the background work of the workspace.
+	stop context.CancelFunc
+}
+
+func newTenants(transport string, logger *zap.Logger) *tenants {
+	// main stops on an invalid interval before any session connects.
+	interval, _ := provider.RefreshIntervalFromEnv()
+
+	t := &tenants{
+		transport:       transport,
+		logger:          logger,
+		refreshInterval: interval,
+		entries:         make(map[string]*tenant),
+	}
+	t.connect = t.connectSlack
+	go t.runEviction(context.Background(), tenantEvictionInterval)
+	return t
+}
+
+// workspace returns the workspace of the credentials carried by ctx,
+// connecting to Slack the first time they are seen.
+func (t *tenants) workspace(ctx context.Context) (*workspace, error) {
+	creds, ok := auth.CredentialsFromContext(ctx)
+	if !ok {
+		return nil, errMissingCredentials
+	}
+	key := creds.Fingerprint()
+	now := time.Now()
+
+	t.mu.Lock()
+	t.evictIdle(now)
+	e, found := t.entries[key]
+	var bg context.Context
+	if !found {
+		e = &tenant{ready: make(chan struct{})}
+		bg, e.stop = context.WithCancel(context.Background())
+		t.entries[key] = e
+	}
+	e.lastUsed = now
+	t.mu.Unlock()
+
+	if !found {
+		e.ws, e.err = t.connect(bg, creds)
+		close(e.ready)
+		if e.err != nil {
+			e.stop()
+			// Forget failed credentials so that a later request retries.
+			t.mu.Lock()
+			if t.entries[key] == e {
+				delete(t.entries, key)
+			}
+			t.mu.Unlock()
+		}
+		return e.ws, e.err
+	}
+
+	select {
+	case <-e.ready:
+		return e.ws, e.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// evictIdle drops the workspaces of credentials unused for tenantIdleTTL
+// and stops their background work. The caller holds t.mu.
+func (t *tenants) evictIdle(now time.Time) {
+	for key, e := range t.entries {
+		if now.Sub(e.lastUsed) > tenantIdleTTL {
+			t.logger.Debug("Dropping idle session workspace", zap.String("tenant", key[:12]))
+			e.stop()
+			delete(t.entries, key)
+		}
+	}
+}
+
+// runEviction drops idle workspaces every interval until ctx is done, so
+// that their background work stops even when no further request arrives.
+func (t *tenants) runEviction(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			t.mu.Lock()
+			t.evictIdle(now)
+			t.mu.Unlock()
+		}
+	}
+}
+
+// connectSlack authenticates creds, then warms and periodically refreshes
+// the caches of the new provider in the background until ctx is done, as
+// main does for the configured workspaces.
+func (t *tenants) connectSlack(ctx context.Context, creds provider.Credentials) (*workspace, error) {
+	logger := t.logger.With(zap.String("tenant", creds.Fingerprint()[:12]))
+
+	p, err := provider.NewWithCredentials(t.transport, creds, logger)
+	if err != nil {
+		logger.Error("Failed to create provider for session credentials", zap.Error(err))
+		return nil, err
+	}
+	ws, _, err := newWorkspace("", p, logger)
+	if err != nil {
+		logger.Error("Failed to authenticate session credentials", zap.Error(err))
+		return nil, err
+	}
+
+	go func() {
+		if err := p.RefreshUsers(ctx); err != nil {
+			logger.Error("Failed to load users for session credentials", zap.Error(err))
+		}
+		if err := p.RefreshChannels(ctx); err != nil {
+			logger.Error("Failed to load channels for session credentials", zap.Error(err))
+		}
+		p.RunRefresh(ctx, t.refreshInterval)
+	}()
+	return ws, nil
+}
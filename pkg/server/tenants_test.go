@@ -0,0 +1,162 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/korotovsky/slack-mcp-server/pkg/server/auth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func requestContext(keys auth.KeyRing, headers map[string]string) context.Context {
+	r := httptest.NewRequest(http.MethodPost, "/mcp", nil)
+	for k, v := range headers {
+		r.Header.Set(k, v)
+	}
+	ctx := auth.AuthFromRequest(zap.NewNop())(context.Background(), r)
+	return auth.CredentialsFromRequest(keys, zap.NewNop())(ctx, r)
+}
+
+func TestUnitTenantsWorkspace(t *testing.T) {
+	tn := newTenants("http", zap.NewNop())
+
+	var connects atomic.Int32
+	var mu sync.Mutex
+	background := map[string]context.Context{}
+	tn.connect = func(ctx context.Context, creds provider.Credentials) (*workspace, error) {
+		connects.Add(1)
+		mu.Lock()
+		background[creds.Token] = ctx
+		mu.Unlock()
+		return &workspace{id: creds.Token}, nil
+	}
+
+	keys := auth.KeyRing{"team-key": {Token: "xoxp-team"}}
+
+	_, err := tn.workspace(requestContext(keys, nil))
+	assert.ErrorIs(t, err, errMissingCredentials)
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ws, err := tn.workspace(requestContext(keys, map[string]string{auth.HeaderSlackToken: "xoxp-alice"}))
+			assert.NoError(t, err)
+			assert.Equal(t, "xoxp-alice", ws.id)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), connects.Load())
+
+	ws, err := tn.workspace(requestContext(keys, map[string]string{"Authorization": "Bearer team-key", auth.HeaderSlackToken: "xoxp-alice"}))
+	require.NoError(t, err)
+	assert.Equal(t, "xoxp-team", ws.id, "a mapped API key wins over the headers")
+	assert.Equal(t, int32(2), connects.Load())
+
+	// Idle credentials are dropped, their background work stopped, and
+	// reconnected on next use.
+	tn.mu.Lock()
+	for _, e := range tn.entries {
+		e.lastUsed = time.Now().Add(-2 * tenantIdleTTL)
+	}
+	tn.mu.Unlock()
+	alice := background["xoxp-alice"]
+	_, err = tn.workspace(requestContext(keys, map[string]string{auth.HeaderSlackToken: "xoxp-alice"}))
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), connects.Load())
+	assert.Len(t, tn.entries, 1)
+	assert.ErrorIs(t, alice.Err(), context.Canceled)
+	assert.ErrorIs(t, background["xoxp-team"].Err(), context.Canceled)
+	assert.NoError(t, background["xoxp-alice"].Err())
+}
+
+func TestUnitTenantsEvictWithoutRequests(t *testing.T) {
+	tn := newTenants("http", zap.NewNop())
+
+	var background context.Context
+	tn.connect = func(ctx context.Context, creds provider.Credentials) (*workspace, error) {
+		background = ctx
+		return &workspace{id: creds.Token}, nil
+	}
+	_, err := tn.workspace(requestContext(nil, map[string]string{auth.HeaderSlackToken: "xoxp-carol"}))
+	require.NoError(t, err)
+
+	tn.mu.Lock()
+	for _, e := range tn.entries {
+		e.lastUsed = time.Now().Add(-2 * tenantIdleTTL)
+	}
+	tn.mu.Unlock()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go tn.runEviction(ctx, time.Millisecond)
+
+	require.Eventually(t, func() bool {
+		return background.Err() != nil
+	}, time.Second, time.Millisecond)
+	tn.mu.Lock()
+	assert.Empty(t, tn.entries)
+	tn.mu.Unlock()
+}
+
+func TestUnitTenantsRetryFailedCredentials(t *testing.T) {
+	tn := newTenants("http", zap.NewNop())
+
+	fail := true
+	var failed context.Context
+	tn.connect = func(ctx context.Context, creds provider.Credentials) (*workspace, error) {
+		if fail {
+			failed = ctx
+			return nil, assert.AnError
+		}
+		return &workspace{id: "ok"}, nil
+	}
+
+	ctx := requestContext(nil, map[string]string{auth.HeaderSlackToken: "xoxp-bob"})
+	_, err := tn.workspace(ctx)
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.ErrorIs(t, failed.Err(), context.Canceled)
+
+	fail = false
+	ws, err := tn.workspace(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "ok", ws.id)
+}
+
+func TestUnitKeyRingAuthentication(t *testing.T) {
+	t.Setenv("SLACK_MCP_API_KEY", "shared-key")
+	keys := auth.KeyRing{"team-key": {Token: "xoxp-team"}}
+
+	ok, _ := auth.IsAuthenticated(requestContext(keys, map[string]string{"Authorization": "Bearer team-key"}), "http", zap.NewNop())
+	assert.True(t, ok)
+
+	ok, _ = auth.IsAuthenticated(requestContext(keys, map[string]string{"Authorization": "Bearer shared-key", auth.HeaderSlackToken: "xoxp-alice"}), "http", zap.NewNop())
+	assert.True(t, ok)
+
+	ok, _ = auth.IsAuthenticated(requestContext(keys, map[string]string{"Authorization": "Bearer other", auth.HeaderSlackToken: "xoxp-alice"}), "http", zap.NewNop())
+	assert.False(t, ok)
+}
+
+func TestUnitLoadKeyRing(t *testing.T) {
+	dir := t.TempDir()
+
+	path := dir + "/keys.json"
+	require.NoError(t, os.WriteFile(path, []byte(`{"k1": {"token": "xoxp-1"}, "k2": {"token": "xoxc-2", "cookie": "xoxd-2"}}`), 0o600))
+	keys, err := auth.LoadKeyRing(path)
+	require.NoError(t, err)
+	assert.Equal(t, provider.Credentials{Token: "xoxc-2", Cookie: "xoxd-2"}, keys["k2"])
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"k1": {"cookie": "xoxd-1"}}`), 0o600))
+	_, err = auth.LoadKeyRing(path)
+	assert.ErrorContains(t, err, "needs a key and a token")
+}
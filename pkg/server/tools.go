@@ -0,0 +1,112 @@
+package server
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/server"
+	"go.uber.org/zap"
+)
+
+// ReadOnlyPreset selects every tool that does not change workspace state
+// when used in SLACK_MCP_ENABLED_TOOLS.
+const ReadOnlyPreset = "read-only"
+
+// mutatingTools lists the tools that post, edit, delete or otherwise change
+// state in Slack. They are never exposed in read-only mode, even when named
+// in the allowlist.
+var mutatingTools = map[string]bool{
+	"conversations_add_message":              true,
+	"conversations_edit_message":             true,
+	"conversations_delete_message":           true,
+	"conversations_schedule_message":         true,
+	"conversations_delete_scheduled_message": true,
+	"conversations_mark":                     true,
+	"reactions_add":                          true,
+	"reactions_remove":                       true,
+}
+
+// toolsFilter decides which registered tools are exposed. An empty allowlist
+// exposes everything, the denylist always wins.
+type toolsFilter struct {
+	readOnly bool
+	enabled  map[string]bool
+	disabled map[string]bool
+}
+
+func parseToolsFilter(enabled, disabled string) toolsFilter {
+	f := toolsFilter{
+		enabled:  splitToolNames(enabled),
+		disabled: splitToolNames(disabled),
+	}
+	if f.enabled[ReadOnlyPreset] {
+		f.readOnly = true
+		delete(f.enabled, ReadOnlyPreset)
+	}
+	return f
+}
+
+func (f toolsFilter) allows(name string) bool {
+	if f.disabled[name] || (f.readOnly && mutatingTools[name]) {
+		return false
+	}
+	if f.enabled[name] {
+		return true
+	}
+	return f.readOnly || len(f.enabled) == 0
+}
+
+// unknown returns the configured names that do not match a registered tool.
+func (f toolsFilter) unknown(registered map[string]*server.ServerTool) []string {
+	var names []string
+	for _, set := range []map[string]bool{f.enabled, f.disabled} {
+		for name := range set {
+			if _, ok := registered[name]; !ok {
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// applyToolsFilter removes the tools not selected by SLACK_MCP_ENABLED_TOOLS
+// and SLACK_MCP_DISABLED_TOOLS, so they are absent from tools/list rather
+// than rejected at call time.
+func applyToolsFilter(s *server.MCPServer, enabled, disabled string, logger *zap.Logger) error {
+	f := parseToolsFilter(enabled, disabled)
+
+	registered := s.ListTools()
+	if unknown := f.unknown(registered); len(unknown) > 0 {
+		return fmt.Errorf("unknown or unavailable tools: %s", strings.Join(unknown, ", "))
+	}
+
+	var hidden []string
+	for name := range registered {
+		if !f.allows(name) {
+			hidden = append(hidden, name)
+		}
+	}
+	if len(hidden) == 0 {
+		return nil
+	}
+	sort.Strings(hidden)
+
+	s.DeleteTools(hidden...)
+	logger.Info("Tools disabled by configuration",
+		zap.String("context", "console"),
+		zap.Strings("tools", hidden),
+	)
+	return nil
+}
+
+func splitToolNames(raw string) map[string]bool {
+	names := make(map[string]bool)
+	for _, name := range strings.Split(raw, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names[name] = true
+		}
+	}
+	return names
+}
@@ -0,0 +1,71 @@
+package server
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestUnitToolsFilterAllows(t *testing.T) {
+	tests := []struct {
+		name     string
+		enabled  string
+		disabled string
+		allowed  []string
+	}{
+		{"everything by default", "", "", []string{"channels_list", "conversations_add_message", "reactions_add"}},
+		{"allowlist", "channels_list, reactions_add", "", []string{"channels_list", "reactions_add"}},
+		{"denylist", "", "reactions_add", []string{"channels_list", "conversations_add_message"}},
+		{"read-only preset", ReadOnlyPreset, "", []string{"channels_list"}},
+		{"read-only ignores mutating names", ReadOnlyPreset + ",reactions_add", "", []string{"channels_list"}},
+		{"denylist wins", "channels_list", "channels_list", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := parseToolsFilter(tt.enabled, tt.disabled)
+
+			var allowed []string
+			for _, name := range []string{"channels_list", "conversations_add_message", "reactions_add"} {
+				if f.allows(name) {
+					allowed = append(allowed, name)
+				}
+			}
+			assert.Equal(t, tt.allowed, allowed)
+		})
+	}
+}
+
+func TestUnitApplyToolsFilter(t *testing.T) {
+	newServer := func() *server.MCPServer {
+		s := server.NewMCPServer("test", "0.0.0", server.WithToolCapabilities(true))
+		noop := func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) { return nil, nil }
+		for _, name := range []string{"channels_list", "conversations_history", "conversations_add_message", "conversations_mark"} {
+			s.AddTool(mcp.NewTool(name), noop)
+		}
+		return s
+	}
+	names := func(s *server.MCPServer) []string {
+		var res []string
+		for name := range s.ListTools() {
+			res = append(res, name)
+		}
+		sort.Strings(res)
+		return res
+	}
+
+	s := newServer()
+	require.NoError(t, applyToolsFilter(s, ReadOnlyPreset, "conversations_history", zap.NewNop()))
+	assert.Equal(t, []string{"channels_list"}, names(s))
+
+	s = newServer()
+	err := applyToolsFilter(s, "channels_list,chanels_info", "", zap.NewNop())
+	assert.EqualError(t, err, "unknown or unavailable tools: chanels_info")
+	assert.Len(t, s.ListTools(), 4)
+}
@@ -0,0 +1,229 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/handler"
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/korotovsky/slack-mcp-server/pkg/realtime"
+	"github.com/korotovsky/slack-mcp-server/pkg/text"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"go.uber.org/zap"
+)
+
+// workspace is a Slack workspace served by this process with the handlers
+// bound to its provider. id is the <ws> part of its resource URIs: the
+// configured name, or the Slack subdomain for the unnamed workspace.
+type workspace struct {
+	id       string
+	provider *provider.ApiProvider
+
+	conversations *handler.ConversationsHandler
+	channels      *handler.ChannelsHandler
+	users         *handler.UsersHandler
+	events        *handler.EventsHandler
+	completions   *handler.CompletionsHandler
+}
+
+// workspaceRouter dispatches tool calls to the workspace named by their
+// workspace argument, the first configured one being the default, or with
+// per-session credentials to the workspace of the session.
+type workspaceRouter struct {
+	list    []*workspace
+	byName  map[string]*workspace
+	tenants *tenants
+}
+
+// newWorkspaceRouter authenticates every workspace and creates its handlers.
+// The real-time ingestor, when enabled, follows the default workspace only.
+// Without configured workspaces every session brings its own, see tenants.
+func newWorkspaceRouter(workspaces *provider.Workspaces, ingestor *realtime.Ingestor, logger *zap.Logger) *workspaceRouter {
+	r := &workspaceRouter{byName: make(map[string]*workspace)}
+	if workspaces.PerSession() {
+		r.tenants = newTenants(workspaces.Transport(), logger)
+	}
+
+	for i, w := range workspaces.All() {
+		logger.Info("Authenticating with Slack API...",
+			zap.String("context", "console"),
+			zap.String("workspace", w.Name),
+		)
+		ws, subdomain, err := newWorkspace(w.Name, w.Provider, logger)
+		if err != nil {
+			logger.Fatal("Failed to authenticate with Slack",
+				zap.String("context", "console"),
+				zap.String("workspace", w.Name),
+				zap.Error(err),
+			)
+		}
+		if i == 0 && ingestor != nil {
+			ws.events = handler.NewEventsHandler(w.Provider, ingestor, logger)
+		}
+		r.add(ws, subdomain)
+	}
+
+	return r
+}
+
+// newWorkspace authenticates p and creates its handlers. It returns the
+// Slack subdomain of the workspace along with it.
+func newWorkspace(name string, p *provider.ApiProvider, logger *zap.Logger) (*workspace, string, error) {
+	ar, err := p.Slack().AuthTest()
+	if err != nil {
+		return nil, "", err
+	}
+
+	logger.Info("Successfully authenticated with Slack",
+		zap.String("context", "console"),
+		zap.String("workspace", name),
+		zap.String("team", ar.Team),
+		zap.String("user", ar.User),
+		zap.String("enterprise", ar.EnterpriseID),
+		zap.String("url", ar.URL),
+	)
+
+	subdomain, err := text.Workspace(ar.URL)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to parse workspace from URL %s: %w", ar.URL, err)
+	}
+
+	ws := &workspace{
+		id:            subdomain,
+		provider:      p,
+		conversations: handler.NewConversationsHandler(p, logger),
+		channels:      handler.NewChannelsHandler(p, logger),
+		users:         handler.NewUsersHandler(p, logger),
+		completions:   handler.NewCompletionsHandler(p, logger),
+	}
+	if name != "" {
+		ws.id = name
+	}
+	return ws, subdomain, nil
+}
+
+// add registers ws under its id and, unless another workspace already took
+// it, under its Slack subdomain.
+func (r *workspaceRouter) add(ws *workspace, subdomain string) {
+	r.list = append(r.list, ws)
+	r.byName[strings.ToLower(ws.id)] = ws
+	if _, taken := r.byName[strings.ToLower(subdomain)]; !taken && subdomain != "" {
+		r.byName[strings.ToLower(subdomain)] = ws
+	}
+}
+
+func (r *workspaceRouter) defaultWorkspace() *workspace {
+	return r.list[0]
+}
+
+// resolve returns the workspace named by a workspace argument, the default
+// one when empty.
+func (r *workspaceRouter) resolve(name string) (*workspace, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return r.defaultWorkspace(), nil
+	}
+	if ws, ok := r.byName[strings.ToLower(name)]; ok {
+		return ws, nil
+	}
+	return nil, fmt.Errorf("unknown workspace %q, available workspaces: %s", name, strings.Join(r.ids(), ", "))
+}
+
+// resolveRequest returns the workspace of the session with per-session
+// credentials, and resolves name otherwise.
+func (r *workspaceRouter) resolveRequest(ctx context.Context, name string) (*workspace, error) {
+	if r.tenants != nil {
+		return r.tenants.workspace(ctx)
+	}
+	return r.resolve(name)
+}
+
+func (r *workspaceRouter) ids() []string {
+	ids := make([]string, 0, len(r.list))
+	for _, ws := range r.list {
+		ids = append(ids, ws.id)
+	}
+	return ids
+}
+
+// withWorkspace adds the workspace parameter to a tool when more than one
+// workspace is configured.
+func (r *workspaceRouter) withWorkspace() mcp.ToolOption {
+	if len(r.list) < 2 {
+		return func(*mcp.Tool) {}
+	}
+	return mcp.WithString("workspace",
+		mcp.Enum(r.ids()...),
+		mcp.Description(fmt.Sprintf("Slack workspace to use. Default is %s.", r.defaultWorkspace().id)),
+	)
+}
+
+type toolMethod[H any] func(H, context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error)
+
+// route returns a tool handler calling method on the handler that pick
+// selects from the workspace the request names.
+func route[H any](r *workspaceRouter, pick func(*workspace) (H, error), method toolMethod[H]) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		ws, err := r.resolveRequest(ctx, request.GetString("workspace", ""))
+		if err != nil {
+			return nil, err
+		}
+		h, err := pick(ws)
+		if err != nil {
+			return nil, err
+		}
+		return method(h, ctx, request)
+	}
+}
+
+func (r *workspaceRouter) conversations(method toolMethod[*handler.ConversationsHandler]) server.ToolHandlerFunc {
+	return route(r, func(ws *workspace) (*handler.ConversationsHandler, error) { return ws.conversations, nil }, method)
+}
+
+func (r *workspaceRouter) channels(method toolMethod[*handler.ChannelsHandler]) server.ToolHandlerFunc {
+	return route(r, func(ws *workspace) (*handler.ChannelsHandler, error) { return ws.channels, nil }, method)
+}
+
+func (r *workspaceRouter) users(method toolMethod[*handler.UsersHandler]) server.ToolHandlerFunc {
+	return route(r, func(ws *workspace) (*handler.UsersHandler, error) { return ws.users, nil }, method)
+}
+
+func (r *workspaceRouter) events(method toolMethod[*handler.EventsHandler]) server.ToolHandlerFunc {
+	return route(r, func(ws *workspace) (*handler.EventsHandler, error) {
+		if ws.events == nil {
+			return nil, fmt.Errorf("real-time events are only ingested for workspace %s", r.defaultWorkspace().id)
+		}
+		return ws.events, nil
+	}, method)
+}
+
+// completionsFor returns the completion handler of the workspace a
+// completion request refers to: the one of the session with per-session
+// credentials, the one in the URI of a resource template, or the default
+// one for prompts.
+func (r *workspaceRouter) completionsFor(ctx context.Context, ref any) (*handler.CompletionsHandler, error) {
+	if r.tenants != nil {
+		ws, err := r.tenants.workspace(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return ws.completions, nil
+	}
+
+	var uri string
+	switch ref := ref.(type) {
+	case map[string]any:
+		uri, _ = ref["uri"].(string)
+	case mcp.ResourceReference:
+		uri = ref.URI
+	}
+	if rest, ok := strings.CutPrefix(uri, "slack://"); ok {
+		name, _, _ := strings.Cut(rest, "/")
+		if ws, err := r.resolve(name); err == nil {
+			return ws.completions, nil
+		}
+	}
+	return r.defaultWorkspace().completions, nil
+}
@@ -0,0 +1,106 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/handler"
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func newTestRouter(t *testing.T) *workspaceRouter {
+	t.Setenv("SLACK_MCP_XOXP_TOKEN", "demo")
+	acme := provider.New("stdio", zap.NewNop())
+	globex := provider.New("stdio", zap.NewNop())
+
+	return newWorkspaceRouter(provider.NewWorkspacesOf(
+		provider.Workspace{Name: "acme", Provider: acme},
+		provider.Workspace{Name: "globex", Provider: globex},
+	), nil, zap.NewNop())
+}
+
+func TestUnitWorkspaceRouterResolve(t *testing.T) {
+	r := newTestRouter(t)
+	acme, globex := r.list[0], r.list[1]
+
+	tests := []struct {
+		name string
+		want *workspace
+	}{
+		{"", acme},
+		{"acme", acme},
+		{"GLOBEX", globex},
+		// Both demo workspaces live on the "_" subdomain, the first one keeps it.
+		{"_", acme},
+	}
+	for _, tt := range tests {
+		ws, err := r.resolve(tt.name)
+		require.NoError(t, err, tt.name)
+		assert.Same(t, tt.want, ws, tt.name)
+	}
+
+	_, err := r.resolve("initech")
+	assert.ErrorContains(t, err, "available workspaces: acme, globex")
+}
+
+func TestUnitWorkspaceRouterRoute(t *testing.T) {
+	r := newTestRouter(t)
+
+	var got *handler.ChannelsHandler
+	h := r.channels(func(ch *handler.ChannelsHandler, _ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		got = ch
+		return mcp.NewToolResultText("ok"), nil
+	})
+
+	call := func(args map[string]any) error {
+		var req mcp.CallToolRequest
+		req.Params.Arguments = args
+		_, err := h(context.Background(), req)
+		return err
+	}
+
+	require.NoError(t, call(map[string]any{}))
+	assert.Same(t, r.list[0].channels, got)
+
+	require.NoError(t, call(map[string]any{"workspace": "globex"}))
+	assert.Same(t, r.list[1].channels, got)
+
+	assert.Error(t, call(map[string]any{"workspace": "initech"}))
+
+	events := r.events(func(*handler.EventsHandler, context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText("ok"), nil
+	})
+	var req mcp.CallToolRequest
+	req.Params.Arguments = map[string]any{"workspace": "globex"}
+	_, err := events(context.Background(), req)
+	assert.ErrorContains(t, err, "only ingested for workspace acme")
+}
+
+func TestUnitWorkspaceRouterWithWorkspace(t *testing.T) {
+	r := newTestRouter(t)
+
+	tool := mcp.NewTool("channels_list", r.withWorkspace())
+	require.Contains(t, tool.InputSchema.Properties, "workspace")
+	assert.Equal(t, []string{"acme", "globex"}, tool.InputSchema.Properties["workspace"].(map[string]any)["enum"])
+
+	r.list = r.list[:1]
+	tool = mcp.NewTool("channels_list", r.withWorkspace())
+	assert.NotContains(t, tool.InputSchema.Properties, "workspace")
+}
+
+func TestUnitWorkspaceRouterCompletionsFor(t *testing.T) {
+	r := newTestRouter(t)
+	completionsFor := func(ref any) *handler.CompletionsHandler {
+		h, err := r.completionsFor(context.Background(), ref)
+		require.NoError(t, err)
+		return h
+	}
+
+	assert.Same(t, r.list[1].completions, completionsFor(map[string]any{"type": "ref/resource", "uri": "slack://globex/channels/{channel_id}/history"}))
+	assert.Same(t, r.list[0].completions, completionsFor(map[string]any{"type": "ref/prompt", "name": "summarize_channel"}))
+	assert.Same(t, r.list[0].completions, completionsFor(map[string]any{"type": "ref/resource", "uri": "slack://initech/users/{user_id}"}))
+}